@@ -0,0 +1,34 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	clierrors "github.com/major-technology/cli/errors"
+)
+
+func TestVerifyProvidedTokenUsesGivenToken(t *testing.T) {
+	// newTestServer asserts the Authorization header carries "test-token"
+	_, client := newTestServer(t, "GET", "/verify", http.StatusOK, VerifyTokenResponse{
+		Active: true,
+		Email:  "ci@example.com",
+	})
+
+	resp, err := client.VerifyProvidedToken("test-token")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Email != "ci@example.com" {
+		t.Fatalf("bad response mapping: %+v", resp)
+	}
+}
+
+func TestVerifyProvidedTokenInactive(t *testing.T) {
+	_, client := newTestServer(t, "GET", "/verify", http.StatusOK, VerifyTokenResponse{Active: false})
+
+	_, err := client.VerifyProvidedToken("test-token")
+	if !errors.Is(err, clierrors.ErrorTokenNotActive) {
+		t.Fatalf("error = %v, want ErrorTokenNotActive", err)
+	}
+}
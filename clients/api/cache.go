@@ -0,0 +1,162 @@
+package api
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	clierrors "github.com/major-technology/cli/errors"
+)
+
+// DefaultCacheMaxAge is how long cached organization and application lists are
+// reused before fetching them again
+const DefaultCacheMaxAge = 5 * time.Minute
+
+// CacheOptions configures NewCachingClient
+type CacheOptions struct {
+	// Dir is where responses are cached; see ResponseCacheDir. Empty disables caching.
+	Dir string
+	// Offline serves every cacheable call from the cache, however old
+	Offline bool
+	// MaxAge is how long cached organization and application lists are reused online.
+	// Zero always fetches fresh lists, though they're still cached for later.
+	MaxAge time.Duration
+}
+
+// cachingClient caches the responses offline mode can serve: the logged-in user,
+// their organizations, and each organization's applications. Online, organization
+// and application lists younger than MaxAge are reused, so pickers open instantly,
+// and every fetched response is saved. Offline, cached responses are served
+// regardless of age, and calls without one fail with ErrorOfflineNoCache.
+type cachingClient struct {
+	APIClient
+	opts CacheOptions
+}
+
+// NewCachingClient wraps inner so the responses offline mode needs are cached.
+// Other calls go straight to inner, which should itself refuse to reach the
+// network when offline (see Client.SetOffline).
+func NewCachingClient(inner APIClient, opts CacheOptions) APIClient {
+	return &cachingClient{APIClient: inner, opts: opts}
+}
+
+// ResponseCacheDir returns where cached API responses are kept, ~/.major/cache/api
+func ResponseCacheDir() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(homeDir, ".major", "cache", "api"), nil
+}
+
+// ClearResponseCache removes every cached API response, e.g. on login and logout,
+// so one user's cached results are never shown to the next
+func ClearResponseCache() error {
+	dir, err := ResponseCacheDir()
+	if err != nil {
+		return err
+	}
+	return os.RemoveAll(dir)
+}
+
+// VerifyToken always checks the session online; the cached copy is only for offline use
+func (c *cachingClient) VerifyToken() (*VerifyTokenResponse, error) {
+	return cachedCall(c, "verify", 0, c.APIClient.VerifyToken)
+}
+
+func (c *cachingClient) GetOrganizations() (*OrganizationsResponse, error) {
+	return cachedCall(c, "organizations", c.opts.MaxAge, c.APIClient.GetOrganizations)
+}
+
+func (c *cachingClient) GetOrganizationApplications(organizationID string) (*GetOrganizationApplicationsResponse, error) {
+	return cachedCall(c, applicationsCacheName(organizationID), c.opts.MaxAge, func() (*GetOrganizationApplicationsResponse, error) {
+		return c.APIClient.GetOrganizationApplications(organizationID)
+	})
+}
+
+func (c *cachingClient) CreateApplication(name, description, organizationID string, themeID *string) (*CreateApplicationResponse, error) {
+	resp, err := c.APIClient.CreateApplication(name, description, organizationID, themeID)
+	if err == nil {
+		c.invalidate(applicationsCacheName(organizationID))
+	}
+	return resp, err
+}
+
+func (c *cachingClient) CreateDemoApplication(organizationID string) (*CreateDemoApplicationResponse, error) {
+	resp, err := c.APIClient.CreateDemoApplication(organizationID)
+	if err == nil {
+		c.invalidate(applicationsCacheName(organizationID))
+	}
+	return resp, err
+}
+
+// applicationsCacheName is the cache entry for an organization's applications
+func applicationsCacheName(organizationID string) string {
+	return "applications-" + organizationID
+}
+
+func (c *cachingClient) path(name string) string {
+	return filepath.Join(c.opts.Dir, filepath.Base(name)+".json")
+}
+
+// invalidate drops the cached response under name, so the next call fetches it again
+func (c *cachingClient) invalidate(name string) {
+	if c.opts.Dir != "" {
+		os.Remove(c.path(name))
+	}
+}
+
+// cachedCall serves the response cached under name when offline, or online when
+// it is younger than maxAge, and otherwise calls fetch and caches its response.
+// Failing to write the cache only means there's nothing to reuse later, so it
+// isn't reported.
+func cachedCall[T any](c *cachingClient, name string, maxAge time.Duration, fetch func() (*T, error)) (*T, error) {
+	if c.opts.Dir == "" {
+		if c.opts.Offline {
+			return nil, clierrors.ErrorOfflineNoCache
+		}
+		return fetch()
+	}
+	path := c.path(name)
+
+	if c.opts.Offline {
+		resp, ok := readCached[T](path, 0)
+		if !ok {
+			return nil, clierrors.ErrorOfflineNoCache
+		}
+		return resp, nil
+	}
+	if maxAge > 0 {
+		if resp, ok := readCached[T](path, maxAge); ok {
+			return resp, nil
+		}
+	}
+
+	resp, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+	if data, err := json.Marshal(resp); err == nil && os.MkdirAll(c.opts.Dir, 0700) == nil {
+		os.WriteFile(path, data, 0600)
+	}
+	return resp, nil
+}
+
+// readCached reads the response cached at path, if it exists and, when maxAge is
+// set, was written within maxAge
+func readCached[T any](path string, maxAge time.Duration) (*T, bool) {
+	info, err := os.Stat(path)
+	if err != nil || (maxAge > 0 && time.Since(info.ModTime()) >= maxAge) {
+		return nil, false
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	var resp T
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, false
+	}
+	return &resp, true
+}
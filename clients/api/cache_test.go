@@ -0,0 +1,106 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	clierrors "github.com/major-technology/cli/errors"
+)
+
+// countingServer serves a fixed organizations list and counts requests
+func countingServer(t *testing.T, requests *int) *Client {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+		w.Write([]byte(`{"organizations":[{"id":"org-1","name":"Acme"}]}`))
+	}))
+	t.Cleanup(srv.Close)
+	return NewClient(srv.URL)
+}
+
+func TestCachingClientServesCachedResponsesOffline(t *testing.T) {
+	dir := t.TempDir()
+	var requests int
+	client := countingServer(t, &requests)
+
+	if _, err := NewCachingClient(client, CacheOptions{Dir: dir}).GetOrganizations(); err != nil {
+		t.Fatalf("online call: %v", err)
+	}
+
+	offline := NewClient("http://127.0.0.1:0")
+	offline.SetOffline(true)
+	resp, err := NewCachingClient(offline, CacheOptions{Dir: dir, Offline: true}).GetOrganizations()
+	if err != nil {
+		t.Fatalf("offline call: %v", err)
+	}
+	if len(resp.Organizations) != 1 || resp.Organizations[0].Name != "Acme" {
+		t.Fatalf("cached response = %+v, want the one fetched online", resp)
+	}
+}
+
+func TestCachingClientReusesFreshLists(t *testing.T) {
+	dir := t.TempDir()
+	var requests int
+	client := NewCachingClient(countingServer(t, &requests), CacheOptions{Dir: dir, MaxAge: time.Minute})
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetOrganizations(); err != nil {
+			t.Fatalf("call %d: %v", i, err)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("requests = %d, want 1 while the cache is fresh", requests)
+	}
+
+	// A stale entry is fetched again
+	old := time.Now().Add(-2 * time.Minute)
+	if err := os.Chtimes(filepath.Join(dir, "organizations.json"), old, old); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.GetOrganizations(); err != nil {
+		t.Fatal(err)
+	}
+	if requests != 2 {
+		t.Errorf("requests = %d, want 2 after the cache went stale", requests)
+	}
+
+	// MaxAge zero, as with --no-cache, always fetches
+	noCache := NewCachingClient(countingServer(t, &requests), CacheOptions{Dir: dir})
+	if _, err := noCache.GetOrganizations(); err != nil {
+		t.Fatal(err)
+	}
+	if requests != 3 {
+		t.Errorf("requests = %d, want 3 with MaxAge zero", requests)
+	}
+}
+
+func TestCachingClientWithoutCacheOffline(t *testing.T) {
+	offline := NewClient("http://127.0.0.1:0")
+	offline.SetOffline(true)
+	client := NewCachingClient(offline, CacheOptions{Dir: t.TempDir(), Offline: true})
+
+	if _, err := client.GetOrganizationApplications("org-1"); !errors.Is(err, clierrors.ErrorOfflineNoCache) {
+		t.Errorf("uncached call: err = %v, want ErrorOfflineNoCache", err)
+	}
+	if _, err := client.GetApplicationInfo("app-1"); !errors.Is(err, clierrors.ErrorOffline) {
+		t.Errorf("uncacheable call: err = %v, want ErrorOffline", err)
+	}
+}
+
+func TestOfflineClientMakesNoRequests(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected %s %s while offline", r.Method, r.URL.Path)
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL)
+	client.SetOffline(true)
+	if _, err := client.StartLogin(); !errors.Is(err, clierrors.ErrorOffline) {
+		t.Fatalf("err = %v, want ErrorOffline", err)
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"runtime"
+	"strings"
 	"time"
 
 	mjrToken "github.com/major-technology/cli/clients/token"
@@ -17,6 +19,9 @@ import (
 type Client struct {
 	baseURL    string
 	httpClient *http.Client
+	userAgent  string
+	offline    bool
+	debugLog   io.Writer
 }
 
 // NewClient creates a new API client with the provided base URL and optional token
@@ -26,9 +31,32 @@ func NewClient(baseURL string) *Client {
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
+		userAgent: UserAgent("dev"),
 	}
 }
 
+// UserAgent returns the User-Agent header the CLI sends, so the server can tell
+// which version and platform is calling, e.g. "major-cli/1.2.3 (darwin/arm64; go1.24.2)"
+func UserAgent(version string) string {
+	return fmt.Sprintf("major-cli/%s (%s/%s; %s)", version, runtime.GOOS, runtime.GOARCH, runtime.Version())
+}
+
+// SetVersion sets the CLI version reported in the User-Agent header
+func (c *Client) SetVersion(version string) {
+	c.userAgent = UserAgent(version)
+}
+
+// SetOffline makes every request fail with ErrorOffline instead of reaching the network
+func (c *Client) SetOffline(offline bool) {
+	c.offline = offline
+}
+
+// SetDebugLog logs every request, with its status, duration and request ID, to w.
+// A nil w turns logging off.
+func (c *Client) SetDebugLog(w io.Writer) {
+	c.debugLog = w
+}
+
 // testTokenOverride lets tests inject a token without the OS keyring.
 var testTokenOverride string
 
@@ -45,6 +73,10 @@ func (c *Client) doRequest(method, path string, body interface{}, response inter
 
 // doRequestInternal is the internal implementation for making HTTP requests
 func (c *Client) doRequestInternal(method, path string, body interface{}, response interface{}, requireAuth bool) error {
+	if c.offline {
+		return clierrors.ErrorOffline
+	}
+
 	var token string
 	if requireAuth {
 		if testTokenOverride != "" {
@@ -60,64 +92,130 @@ func (c *Client) doRequestInternal(method, path string, body interface{}, respon
 		}
 	}
 
-	var reqBody io.Reader
+	return c.doRequestWithToken(method, path, body, response, token)
+}
+
+// doRequestWithToken sends the request authenticated with the given token, or
+// unauthenticated when token is empty. Rate-limited (429) requests are retried
+// after the server's Retry-After delay when it's short enough.
+func (c *Client) doRequestWithToken(method, path string, body interface{}, response interface{}, token string) error {
+	var jsonBody []byte
 	if body != nil {
-		jsonBody, err := json.Marshal(body)
+		var err error
+		jsonBody, err = json.Marshal(body)
 		if err != nil {
 			return clierrors.WrapError("failed to marshal request body", err)
 		}
-		reqBody = bytes.NewBuffer(jsonBody)
+	}
+
+	var (
+		resp     *http.Response
+		respBody []byte
+		err      error
+	)
+	for attempt := 0; ; attempt++ {
+		resp, respBody, err = c.send(method, path, jsonBody, token)
+		if err != nil {
+			return err
+		}
+		if resp.StatusCode != http.StatusTooManyRequests {
+			break
+		}
+
+		wait := retryAfter(resp.Header.Get("Retry-After"), attempt)
+		if attempt >= maxRateLimitRetries || wait > maxRateLimitWait {
+			return withRetryAfter(withRequestID(withRequestContext(errorFromResponse(resp, respBody), method, path), resp), wait)
+		}
+		time.Sleep(wait)
+	}
+
+	// Handle error responses
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return withRequestID(withRequestContext(errorFromResponse(resp, respBody), method, path), resp)
+	}
+
+	// Parse successful response if a response struct is provided
+	if response != nil {
+		if err := json.Unmarshal(respBody, response); err != nil {
+			return clierrors.WrapError("failed to parse response", err)
+		}
+	}
+
+	return nil
+}
+
+// send performs a single HTTP request and reads the full response body
+func (c *Client) send(method, path string, jsonBody []byte, token string) (*http.Response, []byte, error) {
+	var reqBody io.Reader
+	if jsonBody != nil {
+		reqBody = bytes.NewReader(jsonBody)
 	}
 
 	url := c.baseURL + path
 	req, err := http.NewRequest(method, url, reqBody)
 	if err != nil {
-		return clierrors.WrapError("failed to create request", err)
+		return nil, nil, clierrors.WrapError("failed to create request", err)
 	}
 
-	if body != nil {
+	req.Header.Set("User-Agent", c.userAgent)
+	req.Header.Set("Accept", "application/json")
+	// Accept-Encoding is deliberately left unset: the transport then asks for gzip
+	// itself and transparently decompresses the response, which it stops doing
+	// once the header is set by hand
+	if jsonBody != nil {
 		req.Header.Set("Content-Type", "application/json")
 	}
 	if token != "" {
 		req.Header.Set("Authorization", "Bearer "+token)
 	}
 
+	// Query strings are left out of the debug log, since they can carry search terms and IDs
+	logPath, _, _ := strings.Cut(path, "?")
+	start := time.Now()
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return clierrors.WrapError("failed to make request", err)
+		c.logRequest("%s %s failed after %s: %v", method, logPath, time.Since(start).Round(time.Millisecond), err)
+		return nil, nil, clierrors.WrapError("failed to make request", err)
 	}
 	defer resp.Body.Close()
 
+	if requestID := responseRequestID(resp); requestID != "" {
+		c.logRequest("%s %s %d in %s (request ID %s)", method, logPath, resp.StatusCode, time.Since(start).Round(time.Millisecond), requestID)
+	} else {
+		c.logRequest("%s %s %d in %s", method, logPath, resp.StatusCode, time.Since(start).Round(time.Millisecond))
+	}
+
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return clierrors.WrapError("failed to read response", err)
+		return nil, nil, clierrors.WrapError("failed to read response", err)
 	}
 
-	// Handle error responses
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		var errResp *ErrorResponse
-		if err := json.Unmarshal(respBody, &errResp); err == nil && errResp.Error != nil {
-			return ToCLIError(errResp)
-		}
-		// Fallback for unexpected error format
-		errResp = &ErrorResponse{
-			Error: &AppErrorDetail{
-				InternalCode: 9999,
-				ErrorString:  string(respBody),
-				StatusCode:   resp.StatusCode,
-			},
-		}
-		return ToCLIError(errResp)
-	}
+	return resp, respBody, nil
+}
 
-	// Parse successful response if a response struct is provided
-	if response != nil {
-		if err := json.Unmarshal(respBody, response); err != nil {
-			return clierrors.WrapError("failed to parse response", err)
-		}
+// logRequest writes a line to the debug log, if one is set
+func (c *Client) logRequest(format string, args ...any) {
+	if c.debugLog == nil {
+		return
 	}
+	fmt.Fprintf(c.debugLog, "debug: "+format+"\n", args...)
+}
 
-	return nil
+// errorFromResponse converts a non-2xx response into a CLIError
+func errorFromResponse(resp *http.Response, respBody []byte) error {
+	var errResp *ErrorResponse
+	if err := json.Unmarshal(respBody, &errResp); err == nil && errResp.Error != nil {
+		return ToCLIError(errResp)
+	}
+	// Fallback for unexpected error format
+	errResp = &ErrorResponse{
+		Error: &AppErrorDetail{
+			InternalCode: 9999,
+			ErrorString:  string(respBody),
+			StatusCode:   resp.StatusCode,
+		},
+	}
+	return ToCLIError(errResp)
 }
 
 // --- Authentication / User endpoints ---
@@ -160,11 +258,42 @@ func (c *Client) VerifyToken() (*VerifyTokenResponse, error) {
 	return &resp, nil
 }
 
+// VerifyProvidedToken verifies a token that hasn't been stored yet, such as one
+// passed to 'major user login --token', and returns user information
+func (c *Client) VerifyProvidedToken(token string) (*VerifyTokenResponse, error) {
+	var resp VerifyTokenResponse
+	err := c.doRequestWithToken("GET", "/verify", nil, &resp, token)
+	if err != nil {
+		return nil, err
+	}
+
+	if !resp.Active {
+		return nil, clierrors.ErrorTokenNotActive
+	}
+
+	return &resp, nil
+}
+
 // Logout revokes the current token
 func (c *Client) Logout() error {
 	return c.doRequest("POST", "/logout", map[string]interface{}{}, nil)
 }
 
+// ListSessions retrieves the user's active CLI sessions
+func (c *Client) ListSessions() (*ListSessionsResponse, error) {
+	var resp ListSessionsResponse
+	err := c.doRequest("GET", "/sessions", nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// RevokeSession revokes one of the user's CLI sessions
+func (c *Client) RevokeSession(sessionID string) error {
+	return c.doRequest("DELETE", "/sessions/"+url.PathEscape(sessionID), nil, nil)
+}
+
 // --- Organization endpoints ---
 
 // GetOrganizations retrieves the list of organizations for the authenticated user
@@ -177,6 +306,17 @@ func (c *Client) GetOrganizations() (*OrganizationsResponse, error) {
 	return &resp, nil
 }
 
+// GetOrganizationMembers retrieves the members of an organization and their roles
+func (c *Client) GetOrganizationMembers(organizationID string) (*GetOrganizationMembersResponse, error) {
+	var resp GetOrganizationMembersResponse
+	path := fmt.Sprintf("/organizations/%s/members", organizationID)
+	err := c.doRequest("GET", path, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
 // --- Application endpoints ---
 
 // CreateApplication creates a new application with a GitHub repository
@@ -213,9 +353,17 @@ func (c *Client) GetApplicationByRepo(owner, repo string) (*GetApplicationByRepo
 
 // GetApplicationEnv retrieves environment variables for an application
 func (c *Client) GetApplicationEnv(organizationID, applicationID string) (map[string]string, error) {
+	return c.GetApplicationEnvForEnvironment(organizationID, applicationID, "")
+}
+
+// GetApplicationEnvForEnvironment retrieves environment variables for an application in a
+// specific environment without changing the user's environment choice. An empty
+// environmentID uses the currently-selected environment.
+func (c *Client) GetApplicationEnvForEnvironment(organizationID, applicationID, environmentID string) (map[string]string, error) {
 	req := GetApplicationEnvRequest{
 		OrganizationID: organizationID,
 		ApplicationID:  applicationID,
+		EnvironmentID:  environmentID,
 	}
 
 	var resp GetApplicationEnvResponse
@@ -238,11 +386,13 @@ func (c *Client) GetApplicationResources(applicationID string) (*GetApplicationR
 	return &resp, nil
 }
 
-// CreateApplicationVersion creates a new version of an application
-func (c *Client) CreateApplicationVersion(applicationID string, appURL string) (*CreateApplicationVersionResponse, error) {
+// CreateApplicationVersion creates a new version of an application. An empty
+// environmentID deploys to the application's currently-selected environment.
+func (c *Client) CreateApplicationVersion(applicationID, appURL, environmentID string) (*CreateApplicationVersionResponse, error) {
 	req := CreateApplicationVersionRequest{
 		ApplicationID: applicationID,
 		AppURL:        appURL,
+		EnvironmentID: environmentID,
 	}
 
 	var resp CreateApplicationVersionResponse
@@ -373,6 +523,17 @@ func (c *Client) GetDemoResource(orgID string) (*GetDemoResourceResponse, error)
 	return &resp, nil
 }
 
+// ListDemoTemplates retrieves the demo templates available to an organization
+func (c *Client) ListDemoTemplates(orgID string) (*ListDemoTemplatesResponse, error) {
+	var resp ListDemoTemplatesResponse
+	path := fmt.Sprintf("/demo-templates?organizationId=%s", orgID)
+	err := c.doRequest("GET", path, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
 // --- Environment endpoints ---
 
 // GetApplicationEnvironment retrieves the user's current environment choice for an application
@@ -0,0 +1,170 @@
+package api
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strings"
+	"testing"
+
+	clierrors "github.com/major-technology/cli/errors"
+)
+
+func TestSuccessResponseIsParsed(t *testing.T) {
+	_, client := newTestServer(t, "GET", "/organizations", http.StatusOK, OrganizationsResponse{
+		Organizations: []Organization{{ID: "org-1", Name: "Acme"}, {ID: "org-2", Name: "Globex"}},
+	})
+
+	resp, err := client.GetOrganizations()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resp.Organizations) != 2 || resp.Organizations[1].Name != "Globex" {
+		t.Fatalf("bad response mapping: %+v", resp)
+	}
+}
+
+func TestMalformedSuccessBodyIsAnError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("not json"))
+	}))
+	defer srv.Close()
+
+	if _, err := NewClient(srv.URL).GetOrganizations(); err == nil {
+		t.Fatal("expected error for malformed body, got nil")
+	}
+}
+
+func TestUnauthenticatedRequestOmitsBearerToken(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "" {
+			t.Errorf("Authorization header = %q, want none", got)
+		}
+		if got := r.Header.Get("Content-Type"); got != "application/json" {
+			t.Errorf("Content-Type = %q, want application/json", got)
+		}
+		_ = json.NewEncoder(w).Encode(LoginStartResponse{DeviceCode: "dev-1", UserCode: "ABCD"})
+	}))
+	defer srv.Close()
+
+	resp, err := NewClient(srv.URL).StartLogin()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.DeviceCode != "dev-1" || resp.UserCode != "ABCD" {
+		t.Fatalf("bad response mapping: %+v", resp)
+	}
+}
+
+func TestMappedErrorCodesBecomeCLIErrors(t *testing.T) {
+	for code, want := range errorCodeToCLIError {
+		_, client := newTestServer(t, "GET", "/organizations", http.StatusBadRequest, ErrorResponse{
+			Error: &AppErrorDetail{InternalCode: code, ErrorString: "mapped", StatusCode: http.StatusBadRequest},
+		})
+
+		_, err := client.GetOrganizations()
+		if !errors.Is(err, want) {
+			t.Errorf("code %d: error = %v, want %q", code, err, want.Title)
+		}
+	}
+}
+
+func TestErrorWithoutDetailFallsBackToStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	_, err := NewClient(srv.URL).GetOrganizations()
+	if !errors.Is(err, clierrors.ErrorAPIUnavailable) {
+		t.Fatalf("error = %v, want ErrorAPIUnavailable", err)
+	}
+}
+
+func TestCreateApplicationVersionSendsEnvironment(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.RequestURI() != "/applications/versions" {
+			t.Errorf("request = %s %s, want POST /applications/versions", r.Method, r.URL.RequestURI())
+		}
+		var body CreateApplicationVersionRequest
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("bad body: %v", err)
+		}
+		if body.ApplicationID != "app-1" || body.AppURL != "my-app" || body.EnvironmentID != "env-staging" {
+			t.Errorf("body = %+v, want app-1, my-app, env-staging", body)
+		}
+		_ = json.NewEncoder(w).Encode(CreateApplicationVersionResponse{VersionID: "v-1"})
+	}))
+	defer srv.Close()
+
+	resp, err := NewClient(srv.URL).CreateApplicationVersion("app-1", "my-app", "env-staging")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.VersionID != "v-1" {
+		t.Fatalf("bad response mapping: %+v", resp)
+	}
+}
+
+func TestSessionEndpoints(t *testing.T) {
+	_, client := newTestServer(t, "GET", "/sessions", http.StatusOK, ListSessionsResponse{
+		Sessions: []SessionItem{{ID: "s-1", DeviceName: "laptop", Current: true}, {ID: "s-2", DeviceName: "ci"}},
+	})
+	resp, err := client.ListSessions()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resp.Sessions) != 2 || !resp.Sessions[0].Current || resp.Sessions[1].DeviceName != "ci" {
+		t.Fatalf("bad response mapping: %+v", resp)
+	}
+
+	_, client = newTestServer(t, "DELETE", "/sessions/s%2F2", http.StatusOK, struct{}{})
+	if err := client.RevokeSession("s/2"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestRequestsSendUserAgent(t *testing.T) {
+	want := "major-cli/1.2.3 (" + runtime.GOOS + "/" + runtime.GOARCH + "; " + runtime.Version() + ")"
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("User-Agent"); got != want {
+			t.Errorf("User-Agent = %q, want %q", got, want)
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL)
+	client.SetVersion("1.2.3")
+	if _, err := client.StartLogin(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestGzipResponsesAreDecompressed(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Accept"); got != "application/json" {
+			t.Errorf("Accept = %q, want application/json", got)
+		}
+		if !strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
+			t.Errorf("Accept-Encoding = %q, want gzip", r.Header.Get("Accept-Encoding"))
+		}
+		w.Header().Set("Content-Encoding", "gzip")
+		gz := gzip.NewWriter(w)
+		_ = json.NewEncoder(gz).Encode(OrganizationsResponse{Organizations: []Organization{{ID: "org-1", Name: "Acme"}}})
+		_ = gz.Close()
+	}))
+	defer srv.Close()
+
+	resp, err := NewClient(srv.URL).GetOrganizations()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resp.Organizations) != 1 || resp.Organizations[0].Name != "Acme" {
+		t.Fatalf("bad response mapping: %+v", resp)
+	}
+}
@@ -1,7 +1,12 @@
 package api
 
 import (
+	"errors"
 	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	clierrors "github.com/major-technology/cli/errors"
 )
@@ -70,20 +75,195 @@ var errorCodeToCLIError = map[int]*clierrors.CLIError{
 	ErrorCodeGitHubCollaboratorAddFailed: clierrors.ErrorGitHubCollaboratorAddFailed,
 }
 
-// ToCLIError converts an APIError to a CLIError
+// httpStatusToCLIError maps HTTP statuses to CLIErrors for responses whose
+// internal code has no specific mapping in errorCodeToCLIError
+var httpStatusToCLIError = map[int]*clierrors.CLIError{
+	http.StatusTooManyRequests:    clierrors.ErrorRateLimited,
+	http.StatusBadGateway:         clierrors.ErrorAPIUnavailable,
+	http.StatusServiceUnavailable: clierrors.ErrorAPIUnavailable,
+	http.StatusGatewayTimeout:     clierrors.ErrorAPIUnavailable,
+}
+
+// ToCLIError converts an API ErrorResponse to a CLIError
 // If a specific error code mapping exists, it returns that CLIError
 // Otherwise, it creates a generic CLIError with the API error details
+// Every non-2xx response from doRequestInternal goes through here, so commands
+// always receive a CLIError rather than a raw API error
 func ToCLIError(errResp *ErrorResponse) error {
+	if errResp == nil || errResp.Error == nil {
+		return &clierrors.CLIError{
+			Title:      "API Error",
+			Suggestion: "Please try again or contact support if the issue persists.",
+			Err:        fmt.Errorf("API returned an error without details"),
+		}
+	}
+
 	// Check if we have a specific mapping for this error code
 	if cliErr, exists := errorCodeToCLIError[errResp.Error.InternalCode]; exists {
 		return cliErr
 	}
 
+	detail := fmt.Sprintf("HTTP %d, code %d: %s", errResp.Error.StatusCode, errResp.Error.InternalCode, clierrors.Redact(errResp.Error.ErrorString))
+
+	// Fall back to a mapping by HTTP status, keeping the sentinel matchable with errors.Is
+	if statusErr, exists := httpStatusToCLIError[errResp.Error.StatusCode]; exists {
+		return &clierrors.CLIError{
+			Title:      statusErr.Title,
+			Suggestion: statusErr.Suggestion,
+			Err:        fmt.Errorf("%w (%s)", statusErr, detail),
+			StatusCode: errResp.Error.StatusCode,
+		}
+	}
+
 	// No specific mapping - create a generic CLIError with API details
 	return &clierrors.CLIError{
 		Title:      fmt.Sprintf("API Error (Code: %d)", errResp.Error.InternalCode),
 		Suggestion: "Please try again or contact support if the issue persists.",
-		Err:        fmt.Errorf("%s", errResp.Error.ErrorString),
+		Err:        errors.New(detail),
 		StatusCode: errResp.Error.StatusCode,
 	}
 }
+
+// withRequestContext adds the request method and path to the underlying error of
+// a generic API CLIError, for diagnosing unmapped errors. Mapped sentinels are
+// shared across calls and left untouched; only generic errors set StatusCode.
+func withRequestContext(err error, method, path string) error {
+	var cliErr *clierrors.CLIError
+	if !errors.As(err, &cliErr) || cliErr.StatusCode == 0 {
+		return err
+	}
+	path, _, _ = strings.Cut(path, "?")
+	cliErr.Err = fmt.Errorf("%s %s: %w", method, path, cliErr.Err)
+	return cliErr
+}
+
+// requestIDHeaders are the response headers the API and its proxies use for the
+// request ID, in order of preference
+var requestIDHeaders = []string{"X-Request-Id", "X-Correlation-Id", "X-Amzn-Requestid"}
+
+// responseRequestID returns the request ID the server sent with resp, if any
+func responseRequestID(resp *http.Response) string {
+	for _, header := range requestIDHeaders {
+		if id := strings.TrimSpace(resp.Header.Get(header)); id != "" {
+			return id
+		}
+	}
+	return ""
+}
+
+// withRequestID records resp's request ID on err, so PrintError can show it for
+// support. Mapped sentinels are shared, so they're copied rather than modified,
+// and only for server errors: a mapped 4xx such as "application not found" is
+// something the user can fix without support.
+func withRequestID(err error, resp *http.Response) error {
+	requestID := responseRequestID(resp)
+	var cliErr *clierrors.CLIError
+	if requestID == "" || !errors.As(err, &cliErr) {
+		return err
+	}
+	if cliErr.StatusCode != 0 {
+		cliErr.RequestID = requestID
+		return cliErr
+	}
+	if resp.StatusCode < 500 {
+		return err
+	}
+	return &clierrors.CLIError{
+		Title:      cliErr.Title,
+		Suggestion: cliErr.Suggestion,
+		Err:        cliErr,
+		StatusCode: resp.StatusCode,
+		RequestID:  requestID,
+	}
+}
+
+// HasErrorCode reports whether err is, or wraps, the CLIError mapped from the given
+// API error code. It also matches after clierrors.WrapError, which keeps the
+// sentinel's underlying error rather than the sentinel itself.
+func HasErrorCode(err error, code int) bool {
+	cliErr, exists := errorCodeToCLIError[code]
+	if !exists || err == nil {
+		return false
+	}
+	return errors.Is(err, cliErr) || errors.Is(err, cliErr.Err)
+}
+
+// IsUnauthorized reports whether err came from an unauthorized API response
+func IsUnauthorized(err error) bool {
+	return HasErrorCode(err, ErrorCodeUnauthorized)
+}
+
+// IsInvalidToken reports whether err came from an expired or invalid token
+func IsInvalidToken(err error) bool {
+	return HasErrorCode(err, ErrorCodeInvalidToken)
+}
+
+// IsAuthorizationPending reports whether a device login is still waiting for approval
+func IsAuthorizationPending(err error) bool {
+	return HasErrorCode(err, ErrorCodeAuthorizationPending)
+}
+
+// IsNotOrgMember reports whether the user isn't a member of the requested organization
+func IsNotOrgMember(err error) bool {
+	return HasErrorCode(err, ErrorCodeNotOrgMember)
+}
+
+// IsApplicationNotFound reports whether the requested application doesn't exist
+func IsApplicationNotFound(err error) bool {
+	return HasErrorCode(err, ErrorCodeApplicationNotFound)
+}
+
+// IsNoApplicationAccess reports whether the user lacks access to the application
+func IsNoApplicationAccess(err error) bool {
+	return HasErrorCode(err, ErrorCodeNoApplicationAccess)
+}
+
+// IsRateLimited reports whether the API rejected the request for exceeding its rate limit
+func IsRateLimited(err error) bool {
+	return err != nil && errors.Is(err, clierrors.ErrorRateLimited)
+}
+
+// IsTransient reports whether err is likely to succeed on retry: rate limits and
+// unavailable upstreams
+func IsTransient(err error) bool {
+	return IsRateLimited(err) || errors.Is(err, clierrors.ErrorAPIUnavailable)
+}
+
+// IsDuplicateAppName reports whether an application with the same name already exists
+func IsDuplicateAppName(err error) bool {
+	return HasErrorCode(err, ErrorCodeDuplicateAppName)
+}
+
+const (
+	// maxRateLimitRetries is how many times a 429 response is retried before giving up
+	maxRateLimitRetries = 3
+	// maxRateLimitWait is the longest Retry-After delay the client will wait out automatically
+	maxRateLimitWait = 30 * time.Second
+)
+
+// retryAfter parses a Retry-After header given in seconds or as an HTTP date.
+// Without a usable header it backs off exponentially from one second.
+func retryAfter(header string, attempt int) time.Duration {
+	header = strings.TrimSpace(header)
+	if seconds, err := strconv.Atoi(header); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(header); err == nil {
+		if wait := time.Until(at); wait > 0 {
+			return wait
+		}
+		return 0
+	}
+	return time.Second << attempt
+}
+
+// withRetryAfter tells the user how long to wait when a rate-limited request
+// wasn't retried automatically
+func withRetryAfter(err error, wait time.Duration) error {
+	var cliErr *clierrors.CLIError
+	if wait <= 0 || !errors.As(err, &cliErr) || cliErr.StatusCode != http.StatusTooManyRequests {
+		return err
+	}
+	cliErr.Suggestion = fmt.Sprintf("You're being rate limited by Major services. Try again in %s.", wait.Round(time.Second))
+	return cliErr
+}
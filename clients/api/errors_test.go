@@ -0,0 +1,194 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	clierrors "github.com/major-technology/cli/errors"
+)
+
+func TestErrorCodePredicates(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+		_ = json.NewEncoder(w).Encode(ErrorResponse{
+			Error: &AppErrorDetail{
+				InternalCode: ErrorCodeDuplicateAppName,
+				ErrorString:  "duplicate",
+				StatusCode:   http.StatusConflict,
+			},
+		})
+	}))
+	defer srv.Close()
+
+	_, err := NewClient(srv.URL).CreateApplication("app", "desc", "org-1", nil)
+	if !IsDuplicateAppName(err) {
+		t.Fatalf("IsDuplicateAppName(%v) = false, want true", err)
+	}
+	if IsNotOrgMember(err) {
+		t.Fatalf("IsNotOrgMember(%v) = true, want false", err)
+	}
+
+	// Commands usually wrap API errors before returning them
+	wrapped := clierrors.WrapError("failed to create application", err)
+	if !IsDuplicateAppName(wrapped) {
+		t.Fatalf("IsDuplicateAppName(wrapped) = false, want true")
+	}
+
+	if IsDuplicateAppName(nil) {
+		t.Fatalf("IsDuplicateAppName(nil) = true, want false")
+	}
+}
+
+func TestNonJSONErrorBodyBecomesCLIError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+		_, _ = w.Write([]byte("<html>Bad Gateway</html>"))
+	}))
+	defer srv.Close()
+
+	_, err := NewClient(srv.URL).GetOrganizations()
+	cliErr, ok := err.(*clierrors.CLIError)
+	if !ok {
+		t.Fatalf("error type = %T, want *clierrors.CLIError", err)
+	}
+	if cliErr.StatusCode != http.StatusBadGateway {
+		t.Fatalf("StatusCode = %d, want %d", cliErr.StatusCode, http.StatusBadGateway)
+	}
+}
+
+func TestToCLIErrorWithoutDetail(t *testing.T) {
+	if err := ToCLIError(&ErrorResponse{}); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
+
+func TestGenericErrorIncludesRequestContext(t *testing.T) {
+	_, client := newTestServer(t, "GET", "/organizations", http.StatusTeapot, ErrorResponse{
+		Error: &AppErrorDetail{InternalCode: 9999, ErrorString: "boom", StatusCode: http.StatusTeapot},
+	})
+
+	_, err := client.GetOrganizations()
+	cliErr, ok := err.(*clierrors.CLIError)
+	if !ok {
+		t.Fatalf("error type = %T, want *clierrors.CLIError", err)
+	}
+	if cliErr.Title != "API Error (Code: 9999)" {
+		t.Fatalf("Title = %q", cliErr.Title)
+	}
+	want := "GET /organizations: HTTP 418, code 9999: boom"
+	if cliErr.Err == nil || cliErr.Err.Error() != want {
+		t.Fatalf("Err = %v, want %q", cliErr.Err, want)
+	}
+}
+
+func TestRateLimitedStatusMapping(t *testing.T) {
+	err := ToCLIError(&ErrorResponse{
+		Error: &AppErrorDetail{InternalCode: 9999, ErrorString: "slow down", StatusCode: http.StatusTooManyRequests},
+	})
+	if !errors.Is(err, clierrors.ErrorRateLimited) {
+		t.Fatalf("error = %v, want ErrorRateLimited", err)
+	}
+}
+
+func TestRateLimitedRequestIsRetried(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(OrganizationsResponse{})
+	}))
+	defer srv.Close()
+
+	if _, err := NewClient(srv.URL).GetOrganizations(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls != 2 {
+		t.Fatalf("calls = %d, want 2", calls)
+	}
+}
+
+func TestRateLimitedRequestWithLongRetryAfter(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "120")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	_, err := NewClient(srv.URL).GetOrganizations()
+	if !IsRateLimited(err) {
+		t.Fatalf("error = %v, want rate limited", err)
+	}
+	cliErr := err.(*clierrors.CLIError)
+	if !strings.Contains(cliErr.Suggestion, "2m0s") {
+		t.Fatalf("Suggestion = %q, want retry delay", cliErr.Suggestion)
+	}
+}
+
+func TestRequestIDIsRecordedOnErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		code   int
+		want   string
+		is     error
+	}{
+		{name: "unmapped error", status: http.StatusInternalServerError, code: 1234, want: "req-123"},
+		{name: "mapped server error", status: http.StatusInternalServerError, code: ErrorCodeUnauthorized, want: "req-123", is: clierrors.ErrorUnauthorized},
+		{name: "mapped client error", status: http.StatusNotFound, code: ErrorCodeApplicationNotFound, want: "", is: clierrors.ErrorApplicationNotFoundAPI},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("X-Request-Id", "req-123")
+				w.WriteHeader(tt.status)
+				_ = json.NewEncoder(w).Encode(ErrorResponse{
+					Error: &AppErrorDetail{InternalCode: tt.code, ErrorString: "boom", StatusCode: tt.status},
+				})
+			}))
+			defer srv.Close()
+
+			_, err := NewClient(srv.URL).GetOrganizations()
+			wrapped := clierrors.WrapError("failed to fetch organizations", err)
+			if got := clierrors.RequestID(wrapped); got != tt.want {
+				t.Errorf("RequestID = %q, want %q", got, tt.want)
+			}
+			if tt.is != nil && !errors.Is(err, tt.is) {
+				t.Errorf("err = %v, want it to match %v", err, tt.is)
+			}
+		})
+	}
+
+	// The shared sentinel itself is never modified
+	if clierrors.ErrorUnauthorized.RequestID != "" {
+		t.Errorf("ErrorUnauthorized.RequestID = %q, want it untouched", clierrors.ErrorUnauthorized.RequestID)
+	}
+}
+
+func TestDebugLogIncludesRequestID(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-456")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	var log strings.Builder
+	client := NewClient(srv.URL)
+	client.SetDebugLog(&log)
+	if _, err := client.StartLogin(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := log.String()
+	if !strings.HasPrefix(got, "debug: POST /login/start 200 in ") || !strings.Contains(got, "(request ID req-456)") {
+		t.Errorf("debug log = %q, want the method, path, status and request ID", got)
+	}
+}
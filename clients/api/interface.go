@@ -0,0 +1,69 @@
+package api
+
+// APIClient is the set of API calls commands make. *Client implements it;
+// tests can set a fake with singletons.SetAPIClient instead of running an HTTP server.
+type APIClient interface {
+	// Auth
+	StartLogin() (*LoginStartResponse, error)
+	PollLogin(deviceCode string) (*LoginPollResponse, error)
+	VerifyToken() (*VerifyTokenResponse, error)
+	VerifyProvidedToken(token string) (*VerifyTokenResponse, error)
+	Logout() error
+	ListSessions() (*ListSessionsResponse, error)
+	RevokeSession(sessionID string) error
+
+	// Organizations
+	GetOrganizations() (*OrganizationsResponse, error)
+	GetOrganizationMembers(organizationID string) (*GetOrganizationMembersResponse, error)
+	GetOrganizationApplications(organizationID string) (*GetOrganizationApplicationsResponse, error)
+
+	// Applications
+	CreateApplication(name, description, organizationID string, themeID *string) (*CreateApplicationResponse, error)
+	GetApplicationByRepo(owner, repo string) (*GetApplicationByRepoResponse, error)
+	GetApplicationInfo(applicationID string) (*GetApplicationInfoResponse, error)
+	GetApplicationForLink(applicationID string) (*GetApplicationForLinkResponse, error)
+	AddGithubCollaborators(applicationID, githubUsername string) (*AddGithubCollaboratorsResponse, error)
+	CreateApplicationVersion(applicationID, appURL, environmentID string) (*CreateApplicationVersionResponse, error)
+	GetVersionStatus(applicationID, organizationID, versionID string) (*GetVersionStatusResponse, error)
+	GetApplicationLogs(applicationID string, req GetApplicationLogsRequest) (*GetApplicationLogsResponse, error)
+
+	// Environments and env variables
+	GetApplicationEnv(organizationID, applicationID string) (map[string]string, error)
+	GetApplicationEnvForEnvironment(organizationID, applicationID, environmentID string) (map[string]string, error)
+	GetApplicationEnvironment(applicationID string) (*GetApplicationEnvironmentResponse, error)
+	ListApplicationEnvironments(applicationID string) (*ListEnvironmentsResponse, error)
+	SetApplicationEnvironment(applicationID, environmentID string) (*SetEnvironmentChoiceResponse, error)
+	GetEnvVariables(applicationID string) (*GetEnvVariablesResponse, error)
+	SetEnvVariable(applicationID, key, environmentID, value string) (*SetEnvVariableResponse, error)
+	DeleteEnvVariableByKey(applicationID, key, environmentID string, allEnvironments bool) (*DeleteEnvVariableResponse, error)
+
+	// Resources
+	GetResources(organizationID string) (*GetResourcesResponse, error)
+	GetApplicationResources(applicationID string) (*GetApplicationResourcesResponse, error)
+	SaveApplicationResources(organizationID, applicationID string, resourceIDs []string) (*SaveApplicationResourcesResponse, error)
+
+	// Demo
+	CreateDemoApplication(organizationID string) (*CreateDemoApplicationResponse, error)
+	GetDemoResource(orgID string) (*GetDemoResourceResponse, error)
+	ListDemoTemplates(orgID string) (*ListDemoTemplatesResponse, error)
+
+	// Themes
+	GetThemeFiles(applicationID string) (*GetThemeFilesResponse, error)
+	ListThemes(orgID string) (*ListThemesResponse, error)
+	GetThemeVersion(applicationID string) (*GetThemeVersionResponse, error)
+	UpgradeTheme(applicationID string) error
+
+	// Projects
+	CreateProject(name, description, organizationID string) (*CreateProjectResponse, error)
+	GetProjectByRepo(owner, repo string) (*GetProjectByRepoResponse, error)
+	GetProject(projectID, organizationID string) (*GetProjectResponse, error)
+	ListProjectVersions(projectID, organizationID string) (*ListProjectVersionsResponse, error)
+	GetProjectDeployPlan(projectID, organizationID, versionID string) (*GetProjectDeployPlanResponse, error)
+	CreateProjectDeploy(projectID, organizationID, versionID string) (*CreateProjectDeployResponse, error)
+	AddProjectGithubCollaborators(projectID, organizationID, githubUsername string) (*AddGithubCollaboratorsResponse, error)
+
+	// Version check
+	CheckVersion(currentVersion string) (*CheckVersionResponse, error)
+}
+
+var _ APIClient = (*Client)(nil)
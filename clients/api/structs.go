@@ -34,6 +34,21 @@ type VerifyTokenResponse struct {
 	Exp    int64           `json:"exp,omitempty"`
 }
 
+// SessionItem represents one of the user's active CLI sessions
+type SessionItem struct {
+	ID         string `json:"id"`
+	DeviceName string `json:"device_name,omitempty"`
+	CreatedAt  string `json:"created_at,omitempty"`
+	LastUsedAt string `json:"last_used_at,omitempty"`
+	Current    bool   `json:"current,omitempty"`
+}
+
+// ListSessionsResponse represents the response from GET /sessions
+type ListSessionsResponse struct {
+	Error    *AppErrorDetail `json:"error,omitempty"`
+	Sessions []SessionItem   `json:"sessions,omitempty"`
+}
+
 // --- Organization structs ---
 
 // Organization represents an organization from the API
@@ -48,6 +63,20 @@ type OrganizationsResponse struct {
 	Organizations []Organization  `json:"organizations,omitempty"`
 }
 
+// OrganizationMember is a user in an organization
+type OrganizationMember struct {
+	UserID string `json:"userId"`
+	Name   string `json:"name"`
+	Email  string `json:"email"`
+	Role   string `json:"role"`
+}
+
+// GetOrganizationMembersResponse represents the response from GET /organizations/:organizationId/members
+type GetOrganizationMembersResponse struct {
+	Error   *AppErrorDetail      `json:"error,omitempty"`
+	Members []OrganizationMember `json:"members,omitempty"`
+}
+
 // --- Application structs ---
 
 // CreateApplicationRequest represents the request body for POST /applications
@@ -87,6 +116,7 @@ type GetApplicationByRepoResponse struct {
 type GetApplicationEnvRequest struct {
 	OrganizationID string `json:"organizationId"`
 	ApplicationID  string `json:"applicationId"`
+	EnvironmentID  string `json:"environmentId,omitempty"`
 }
 
 // GetApplicationEnvResponse represents the response from POST /application/env
@@ -113,6 +143,7 @@ type GetApplicationResourcesResponse struct {
 type CreateApplicationVersionRequest struct {
 	ApplicationID string `json:"applicationId"`
 	AppURL        string `json:"appURL,omitempty"`
+	EnvironmentID string `json:"environmentId,omitempty"`
 }
 
 // CreateApplicationVersionResponse represents the response from POST /applications/versions
@@ -231,6 +262,23 @@ type GetDemoResourceResponse struct {
 	Resource *ResourceItem   `json:"resource,omitempty"`
 }
 
+// DemoTemplateItem is a template repository 'major demo create' can start from.
+// Recommended marks the template the server suggests for most new users.
+type DemoTemplateItem struct {
+	ID            string `json:"id"`
+	Name          string `json:"name"`
+	Description   string `json:"description,omitempty"`
+	Recommended   bool   `json:"recommended,omitempty"`
+	CloneURLSSH   string `json:"cloneUrlSsh"`
+	CloneURLHTTPS string `json:"cloneUrlHttps"`
+}
+
+// ListDemoTemplatesResponse represents the response from GET /demo-templates
+type ListDemoTemplatesResponse struct {
+	Error     *AppErrorDetail    `json:"error,omitempty"`
+	Templates []DemoTemplateItem `json:"templates,omitempty"`
+}
+
 // --- Environment structs ---
 
 // EnvironmentItem represents a single environment
@@ -315,11 +363,13 @@ type SetEnvironmentChoiceResponse struct {
 
 // GetApplicationInfoResponse represents the response from GET /applications/:applicationId/info
 type GetApplicationInfoResponse struct {
-	Error        *AppErrorDetail `json:"error,omitempty"`
-	ApplicationID string         `json:"applicationId,omitempty"`
-	Name          string         `json:"name,omitempty"`
-	AppURL        *string        `json:"appUrl,omitempty"`
-	DeployStatus  string         `json:"deployStatus,omitempty"`
+	Error         *AppErrorDetail `json:"error,omitempty"`
+	ApplicationID string          `json:"applicationId,omitempty"`
+	Name          string          `json:"name,omitempty"`
+	AppURL        *string         `json:"appUrl,omitempty"`
+	DeployStatus  string          `json:"deployStatus,omitempty"`
+	// DeployedCommitHash is the commit of the live version; nil before the first deploy
+	DeployedCommitHash *string `json:"deployedCommitHash,omitempty"`
 }
 
 // GetApplicationForLinkResponse represents the response from GET /application/:applicationId/link-info
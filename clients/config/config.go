@@ -1,9 +1,18 @@
 package config
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
 	"strings"
+	"time"
 
 	"github.com/major-technology/cli/configs"
+	clierrors "github.com/major-technology/cli/errors"
 	"github.com/spf13/viper"
 )
 
@@ -14,9 +23,32 @@ type Config struct {
 	FrontendURI        string `mapstructure:"frontend_uri"`
 	AppURLSuffix       string `mapstructure:"app_url_suffix"`
 	AppURLFEOnlySuffix string `mapstructure:"app_url_fe_only_suffix"`
+	// CredentialStore selects where credentials are kept: "auto", "keyring", or "file"
+	CredentialStore string `mapstructure:"credential_store"`
+	// DemoRepoSSH and DemoRepoHTTPS are the template repository 'major demo create' clones
+	DemoRepoSSH   string `mapstructure:"demo_repo_ssh"`
+	DemoRepoHTTPS string `mapstructure:"demo_repo_https"`
+	// Offline serves cached results instead of calling the API; set with --offline or MAJOR_OFFLINE
+	Offline bool `mapstructure:"offline"`
+	// NoCache always fetches organization and application lists instead of reusing
+	// recently cached ones; set with --no-cache or MAJOR_NO_CACHE
+	NoCache bool `mapstructure:"no_cache"`
+	// Debug logs every API request with its status and request ID to stderr; set with --debug or MAJOR_DEBUG
+	Debug bool `mapstructure:"debug"`
+	// Telemetry allows non-essential features such as the optional upgrade notice; turn
+	// it off with 'major config set telemetry off' or MAJOR_TELEMETRY=0
+	Telemetry bool `mapstructure:"telemetry"`
+	// CommandTimeout and LongCommandTimeout replace the time limits for quick commands
+	// (e.g. git status) and for downloads and installs (e.g. git clone, pnpm install),
+	// as durations such as "2m"; override with MAJOR_COMMAND_TIMEOUT and
+	// MAJOR_LONG_COMMAND_TIMEOUT. Unset keeps the built-in limits.
+	CommandTimeout     time.Duration `mapstructure:"command_timeout"`
+	LongCommandTimeout time.Duration `mapstructure:"long_command_timeout"`
 }
 
-// Load initializes and returns the application config
+// Load initializes and returns the application config. configFile is either one
+// of the embedded configs ("configs/local.json", "configs/staging.json",
+// "configs/prod.json") or the path of a JSON config file on disk.
 func Load(configFile string) (*Config, error) {
 	v := viper.New()
 
@@ -25,14 +57,37 @@ func Load(configFile string) (*Config, error) {
 	v.AutomaticEnv()
 	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
 
+	// AutomaticEnv only covers keys viper already knows about, so without this a
+	// MAJOR_* override is dropped whenever the config file leaves the key out
+	for _, key := range configKeys() {
+		if err := v.BindEnv(key); err != nil {
+			return nil, err
+		}
+	}
+
+	// Not part of the embedded configs; override with MAJOR_CREDENTIAL_STORE
+	v.SetDefault("credential_store", "auto")
+
+	// Every environment uses the same demo template unless its config says otherwise
+	v.SetDefault("demo_repo_ssh", "git@github.com:major-technology/vite-api-usage-demo.git")
+	v.SetDefault("demo_repo_https", "https://github.com/major-technology/vite-api-usage-demo.git")
+
+	v.SetDefault("telemetry", true)
+
 	var configData []byte
 	switch configFile {
 	case "configs/prod.json":
 		configData = configs.ProdConfig
 	case "configs/staging.json":
 		configData = configs.StagingConfig
-	default:
+	case "configs/local.json", "":
 		configData = configs.LocalConfig
+	default:
+		data, err := os.ReadFile(configFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read config file: %w", err)
+		}
+		configData = data
 	}
 
 	// Set config type and read from embedded config
@@ -41,11 +96,133 @@ func Load(configFile string) (*Config, error) {
 		return nil, err
 	}
 
+	// Settings saved with 'major config set' apply on top of the config file
+	if err := mergeSettings(v); err != nil {
+		return nil, err
+	}
+
 	// Unmarshal into Config struct
 	var cfg Config
 	if err := v.Unmarshal(&cfg); err != nil {
 		return nil, err
 	}
 
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+
 	return &cfg, nil
 }
+
+// SettingsPath returns the path of the user settings file 'major config set' writes
+func SettingsPath() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(homeDir, ".major", "settings.json"), nil
+}
+
+// SaveSetting stores value under key in the user settings file, keeping the other settings
+func SaveSetting(key string, value any) error {
+	path, err := SettingsPath()
+	if err != nil {
+		return err
+	}
+
+	settings := map[string]any{}
+	if data, err := os.ReadFile(path); err == nil {
+		if err := json.Unmarshal(data, &settings); err != nil {
+			return fmt.Errorf("failed to parse settings file %s: %w", path, err)
+		}
+	} else if !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read settings file: %w", err)
+	}
+	settings[key] = value
+
+	data, err := json.MarshalIndent(settings, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write settings file: %w", err)
+	}
+	return nil
+}
+
+// mergeSettings applies the user settings file, if there is one, to v
+func mergeSettings(v *viper.Viper) error {
+	path, err := SettingsPath()
+	if err != nil {
+		return nil
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to read settings file: %w", err)
+	}
+	if err := v.MergeConfig(bytes.NewReader(data)); err != nil {
+		return fmt.Errorf("failed to parse settings file %s: %w", path, err)
+	}
+	return nil
+}
+
+// configKeys returns the mapstructure key of every Config field
+func configKeys() []string {
+	t := reflect.TypeOf(Config{})
+	keys := make([]string, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		if key := t.Field(i).Tag.Get("mapstructure"); key != "" {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// validate checks that the URLs the CLI depends on are present and well-formed,
+// so a typo in a MAJOR_* override fails here rather than on the first request
+func (c *Config) validate() error {
+	var problems []string
+
+	required := []struct{ key, value string }{
+		{"api_url", c.APIURL},
+		{"frontend_uri", c.FrontendURI},
+	}
+	for _, field := range required {
+		if field.value == "" {
+			problems = append(problems, fmt.Sprintf("%s is missing", field.key))
+		} else if !isHTTPURL(field.value) {
+			problems = append(problems, fmt.Sprintf("%s %q is not an http(s) URL", field.key, field.value))
+		}
+	}
+	if c.ResourceAPIURL != "" && !isHTTPURL(c.ResourceAPIURL) {
+		problems = append(problems, fmt.Sprintf("resource_api_url %q is not an http(s) URL", c.ResourceAPIURL))
+	}
+	if c.CommandTimeout < 0 {
+		problems = append(problems, fmt.Sprintf("command_timeout %s is negative", c.CommandTimeout))
+	}
+	if c.LongCommandTimeout < 0 {
+		problems = append(problems, fmt.Sprintf("long_command_timeout %s is negative", c.LongCommandTimeout))
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+
+	detail := strings.Join(problems, "; ")
+	return &clierrors.CLIError{
+		Title:      "Invalid configuration: " + detail,
+		Suggestion: "Check the config file and any MAJOR_* environment variables that override it (e.g. MAJOR_API_URL).",
+		Err:        fmt.Errorf("%w: %s", clierrors.ErrorInvalidConfig, detail),
+	}
+}
+
+// isHTTPURL reports whether s is an absolute http or https URL with a host
+func isHTTPURL(s string) bool {
+	u, err := url.Parse(s)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
@@ -0,0 +1,161 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	clierrors "github.com/major-technology/cli/errors"
+)
+
+func TestLoadExternalFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "self-hosted.json")
+	if err := os.WriteFile(path, []byte(`{"api_url": "https://major.internal.example/api", "frontend_uri": "https://major.internal.example"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.APIURL != "https://major.internal.example/api" {
+		t.Fatalf("APIURL = %q", cfg.APIURL)
+	}
+	if cfg.CredentialStore != "auto" {
+		t.Fatalf("CredentialStore = %q, want default %q", cfg.CredentialStore, "auto")
+	}
+}
+
+func TestLoadMissingExternalFile(t *testing.T) {
+	if _, err := Load(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Fatal("expected error for missing config file")
+	}
+}
+
+func TestLoadRejectsMalformedOverride(t *testing.T) {
+	t.Setenv("MAJOR_API_URL", "htp://api.example.com")
+
+	_, err := Load("configs/prod.json")
+	if !errors.Is(err, clierrors.ErrorInvalidConfig) {
+		t.Fatalf("error = %v, want ErrorInvalidConfig", err)
+	}
+	if !strings.Contains(err.Error(), "api_url") {
+		t.Fatalf("error %q doesn't name the bad field", err)
+	}
+}
+
+func TestLoadEnvOverridesEmbeddedConfig(t *testing.T) {
+	t.Setenv("MAJOR_API_URL", "https://api.self-hosted.example/cli")
+	t.Setenv("MAJOR_FRONTEND_URI", "https://self-hosted.example")
+
+	cfg, err := Load("configs/prod.json")
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.APIURL != "https://api.self-hosted.example/cli" {
+		t.Errorf("APIURL = %q, want the MAJOR_API_URL override", cfg.APIURL)
+	}
+	if cfg.FrontendURI != "https://self-hosted.example" {
+		t.Errorf("FrontendURI = %q, want the MAJOR_FRONTEND_URI override", cfg.FrontendURI)
+	}
+}
+
+func TestConfigKeysCoverAllFields(t *testing.T) {
+	keys := configKeys()
+	if len(keys) != reflect.TypeOf(Config{}).NumField() {
+		t.Fatalf("configKeys() = %v, want one key per Config field", keys)
+	}
+	for _, key := range []string{"api_url", "frontend_uri", "resource_api_url", "credential_store"} {
+		if !slices.Contains(keys, key) {
+			t.Errorf("configKeys() is missing %q", key)
+		}
+	}
+}
+
+func TestLoadEnvOverridesKeysMissingFromConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "minimal.json")
+	if err := os.WriteFile(path, []byte(`{"api_url": "https://major.internal.example/api", "frontend_uri": "https://major.internal.example"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("MAJOR_API_URL", "https://override.example/api")
+	t.Setenv("MAJOR_RESOURCE_API_URL", "https://resources.example")
+	t.Setenv("MAJOR_APP_URL_FE_ONLY_SUFFIX", "apps.example")
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.APIURL != "https://override.example/api" {
+		t.Errorf("APIURL = %q, want the MAJOR_API_URL override", cfg.APIURL)
+	}
+	if cfg.ResourceAPIURL != "https://resources.example" {
+		t.Errorf("ResourceAPIURL = %q, want the MAJOR_RESOURCE_API_URL override", cfg.ResourceAPIURL)
+	}
+	if cfg.AppURLFEOnlySuffix != "apps.example" {
+		t.Errorf("AppURLFEOnlySuffix = %q, want the MAJOR_APP_URL_FE_ONLY_SUFFIX override", cfg.AppURLFEOnlySuffix)
+	}
+}
+
+func TestEmbeddedConfigsAreValid(t *testing.T) {
+	for _, name := range []string{"configs/local.json", "configs/staging.json", "configs/prod.json"} {
+		if _, err := Load(name); err != nil {
+			t.Errorf("Load(%q): %v", name, err)
+		}
+	}
+}
+
+func TestLoadAppliesSavedSettings(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	cfg, err := Load("configs/prod.json")
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if !cfg.Telemetry {
+		t.Error("Telemetry = false, want on by default")
+	}
+
+	if err := SaveSetting("telemetry", false); err != nil {
+		t.Fatalf("SaveSetting: %v", err)
+	}
+	if cfg, err = Load("configs/prod.json"); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.Telemetry {
+		t.Error("Telemetry = true, want the saved setting")
+	}
+	if cfg.APIURL == "" {
+		t.Error("APIURL is empty, saved settings replaced the config file")
+	}
+
+	t.Setenv("MAJOR_TELEMETRY", "1")
+	if cfg, err = Load("configs/prod.json"); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if !cfg.Telemetry {
+		t.Error("Telemetry = false, want the MAJOR_TELEMETRY override")
+	}
+}
+
+func TestLoadCommandTimeouts(t *testing.T) {
+	t.Setenv("MAJOR_COMMAND_TIMEOUT", "2m")
+	t.Setenv("MAJOR_LONG_COMMAND_TIMEOUT", "1h")
+
+	cfg, err := Load("configs/prod.json")
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.CommandTimeout != 2*time.Minute || cfg.LongCommandTimeout != time.Hour {
+		t.Errorf("CommandTimeout, LongCommandTimeout = %s, %s, want 2m, 1h", cfg.CommandTimeout, cfg.LongCommandTimeout)
+	}
+
+	t.Setenv("MAJOR_COMMAND_TIMEOUT", "-1s")
+	if _, err := Load("configs/prod.json"); !errors.Is(err, clierrors.ErrorInvalidConfig) {
+		t.Errorf("negative timeout: error = %v, want ErrorInvalidConfig", err)
+	}
+}
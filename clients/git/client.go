@@ -1,8 +1,10 @@
 package git
 
 import (
-	"context"
+	"bytes"
 	"errors"
+	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"regexp"
@@ -10,6 +12,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/major-technology/cli/clients/process"
 	clierrors "github.com/major-technology/cli/errors"
 )
 
@@ -27,7 +30,7 @@ func GetRemoteURL() (string, error) {
 // GetRemoteURLFromDir retrieves the git remote URL from the specified directory.
 // If dir is empty, it uses the current directory.
 func GetRemoteURLFromDir(dir string) (string, error) {
-	cmd := exec.Command("git", "remote", "get-url", "origin")
+	cmd := process.Command("git", "remote", "get-url", "origin")
 	if dir != "" {
 		cmd.Dir = dir
 	}
@@ -49,41 +52,128 @@ func GetRemoteURLFromDir(dir string) (string, error) {
 
 // Clone clones a git repository
 func Clone(url, targetDir string) error {
-	cmd := exec.Command("git", "clone", url, targetDir)
+	cmd := process.CommandTimeout(process.LongTimeout, "git", "clone", url, targetDir)
 	output, err := cmd.CombinedOutput()
 	if err != nil {
 		// Include the git output in the error message
-		return clierrors.WrapError("git clone failed: "+string(output), err)
+		return commandError("git clone", string(output), err)
+	}
+	return nil
+}
+
+// CloneShallow clones only the latest commit of the default branch.
+// The result can't be pushed to an empty repository as-is; call ResetHistory first.
+func CloneShallow(url, targetDir string) error {
+	cmd := process.CommandTimeout(process.LongTimeout, "git", "clone", "--depth", "1", "--single-branch", url, targetDir)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return commandError("git clone", string(output), err)
+	}
+	return nil
+}
+
+// ResetHistory replaces the history of repoDir with a single root commit on main
+// containing the current tree. This makes a shallow clone pushable to a new remote.
+// The commit uses the user's git identity, or a Major CLI one if none is configured.
+func ResetHistory(repoDir, message string) error {
+	// Config flags go before the subcommand, which names the step in errors
+	var identity []string
+	if !hasIdentity(repoDir) {
+		identity = []string{"-c", "user.name=Major CLI", "-c", "user.email=noreply@major.build"}
+	}
+
+	steps := []struct {
+		config []string
+		args   []string
+	}{
+		{nil, []string{"checkout", "--quiet", "--orphan", "major-reset-history"}},
+		{identity, []string{"commit", "--quiet", "-m", message}},
+		{nil, []string{"branch", "-M", "main"}},
+	}
+	for _, step := range steps {
+		cmd := process.CommandTimeout(process.LongTimeout, "git", append(step.config, step.args...)...)
+		cmd.Dir = repoDir
+		output, err := cmd.CombinedOutput()
+		if err != nil {
+			return commandError("git "+step.args[0], string(output), err)
+		}
+	}
+	return nil
+}
+
+// hasIdentity reports whether git can commit in repoDir without being told who the
+// author is, e.g. on a fresh CI runner with no user.name or user.email
+func hasIdentity(repoDir string) bool {
+	for _, ident := range []string{"GIT_AUTHOR_IDENT", "GIT_COMMITTER_IDENT"} {
+		cmd := process.Command("git", "var", ident)
+		cmd.Dir = repoDir
+		if err := cmd.Run(); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// CommandError is a failed git command together with what git printed, so callers
+// can inspect git's message rather than just the exit status
+type CommandError struct {
+	// Action names the command, e.g. "git clone"
+	Action string
+	// Output is git's stderr, or its combined output for commands that capture both
+	Output string
+	Err    error
+}
+
+func (e *CommandError) Error() string {
+	if e.Output == "" {
+		return fmt.Sprintf("%s failed: %v", e.Action, e.Err)
+	}
+	return fmt.Sprintf("%s failed: %s", e.Action, e.Output)
+}
+
+func (e *CommandError) Unwrap() error {
+	return e.Err
+}
+
+// commandError wraps a failed git command in a CLIError whose title shows git's
+// output, keeping a CommandError in the chain for callers that inspect it
+func commandError(action, output string, err error) error {
+	cmdErr := &CommandError{Action: action, Output: strings.TrimSpace(output), Err: err}
+	return clierrors.WrapError(cmdErr.Error(), cmdErr)
+}
+
+// runStreaming runs cmd with its output on the terminal, keeping a copy of
+// stderr so a failure carries git's own message rather than just the exit status
+func runStreaming(cmd *process.Cmd, action string) error {
+	var stderr bytes.Buffer
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = io.MultiWriter(os.Stderr, &stderr)
+	if err := cmd.Run(); err != nil {
+		return commandError(action, stderr.String(), err)
 	}
 	return nil
 }
 
 // RemoveRemote removes a git remote
 func RemoveRemote(repoDir, remoteName string) error {
-	cmd := exec.Command("git", "remote", "remove", remoteName)
+	cmd := process.Command("git", "remote", "remove", remoteName)
 	cmd.Dir = repoDir
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	return cmd.Run()
+	return runStreaming(cmd, "git remote remove")
 }
 
 // AddRemote adds a git remote
 func AddRemote(repoDir, remoteName, url string) error {
-	cmd := exec.Command("git", "remote", "add", remoteName, url)
+	cmd := process.Command("git", "remote", "add", remoteName, url)
 	cmd.Dir = repoDir
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	return cmd.Run()
+	return runStreaming(cmd, "git remote add")
 }
 
 // Push pushes to the remote repository
 func Push(repoDir string) error {
-	cmd := exec.Command("git", "push", "--force", "-u", "origin", "main")
+	cmd := process.CommandTimeout(process.LongTimeout, "git", "push", "--force", "-u", "origin", "main")
 	cmd.Dir = repoDir
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
 	cmd.Stdin = os.Stdin
-	return cmd.Run()
+	return runStreaming(cmd, "git push")
 }
 
 // ParseRemoteURL parses a git remote URL and extracts the owner and repository name
@@ -117,7 +207,7 @@ func ParseRemoteURL(remoteURL string) (*RemoteInfo, error) {
 
 // GetRepoRoot returns the root directory of the git repository
 func GetRepoRoot() (string, error) {
-	cmd := exec.Command("git", "rev-parse", "--show-toplevel")
+	cmd := process.Command("git", "rev-parse", "--show-toplevel")
 	output, err := cmd.Output()
 	if err != nil {
 		return "", err
@@ -134,7 +224,7 @@ func IsGitRepository() bool {
 // IsGitRepositoryDir checks if the specified directory is a git repository.
 // If dir is empty, it uses the current directory.
 func IsGitRepositoryDir(dir string) bool {
-	cmd := exec.Command("git", "rev-parse", "--git-dir")
+	cmd := process.Command("git", "rev-parse", "--git-dir")
 	if dir != "" {
 		cmd.Dir = dir
 	}
@@ -145,13 +235,13 @@ func IsGitRepositoryDir(dir string) bool {
 // InitRepository initializes a new git repository in the specified directory.
 // If dir is empty, it uses the current directory.
 func InitRepository(dir string) error {
-	cmd := exec.Command("git", "init")
+	cmd := process.Command("git", "init")
 	if dir != "" {
 		cmd.Dir = dir
 	}
 	output, err := cmd.CombinedOutput()
 	if err != nil {
-		return clierrors.WrapError("git init failed: "+string(output), err)
+		return commandError("git init", string(output), err)
 	}
 	return nil
 }
@@ -160,70 +250,162 @@ func InitRepository(dir string) error {
 // If the remote doesn't exist, it adds it. If it exists, it updates it.
 func SetRemoteURL(dir, remoteName, url string) error {
 	// First try to set the URL (works if remote exists)
-	cmd := exec.Command("git", "remote", "set-url", remoteName, url)
+	cmd := process.Command("git", "remote", "set-url", remoteName, url)
 	if dir != "" {
 		cmd.Dir = dir
 	}
 	if err := cmd.Run(); err != nil {
 		// Remote doesn't exist, add it
-		cmd = exec.Command("git", "remote", "add", remoteName, url)
+		cmd = process.Command("git", "remote", "add", remoteName, url)
 		if dir != "" {
 			cmd.Dir = dir
 		}
 		output, err := cmd.CombinedOutput()
 		if err != nil {
-			return clierrors.WrapError("failed to add remote: "+string(output), err)
+			return commandError("git remote add", string(output), err)
 		}
 	}
 	return nil
 }
 
+// FileStatus is a single changed file reported by git status
+type FileStatus struct {
+	// State is the two-character porcelain status, e.g. " M", "A ", "R ", or "??"
+	State string
+	// Path is the file path relative to the repository root (the new path for renames)
+	Path string
+	// OrigPath is the previous path for renamed or copied files
+	OrigPath string
+}
+
 // HasUncommittedChanges checks if there are uncommitted changes in the repository
 func HasUncommittedChanges() (bool, error) {
-	// Check for staged and unstaged changes
-	cmd := exec.Command("git", "status", "--porcelain")
-	output, err := cmd.Output()
+	changes, err := Status("")
 	if err != nil {
 		return false, err
 	}
+	return len(changes) > 0, nil
+}
+
+// Status lists staged, unstaged, and untracked files in the repository at dir.
+// Untracked directories are expanded so every file that `git add .` would stage is listed.
+// If dir is empty, it uses the current directory.
+func Status(dir string) ([]FileStatus, error) {
+	cmd := process.Command("git", "status", "--porcelain", "-z", "--untracked-files=all")
+	if dir != "" {
+		cmd.Dir = dir
+	}
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, err
+	}
 
-	return len(strings.TrimSpace(string(output))) > 0, nil
+	return parseStatus(output), nil
+}
+
+// parseStatus parses `git status --porcelain -z` output. Each entry is "XY path",
+// NUL-terminated; renames and copies are followed by a second NUL-terminated
+// field holding the original path.
+func parseStatus(output []byte) []FileStatus {
+	fields := strings.Split(string(output), "\x00")
+
+	var changes []FileStatus
+	for i := 0; i < len(fields); i++ {
+		field := fields[i]
+		if len(field) < 4 {
+			continue
+		}
+
+		change := FileStatus{State: field[:2], Path: field[3:]}
+		if (change.State[0] == 'R' || change.State[0] == 'C') && i+1 < len(fields) {
+			i++
+			change.OrigPath = fields[i]
+		}
+		changes = append(changes, change)
+	}
+
+	return changes
+}
+
+// GetTrackedFiles returns which of the given paths, relative to the repository
+// root, are tracked by git in the current repository
+func GetTrackedFiles(paths ...string) ([]string, error) {
+	args := []string{"ls-files", "--full-name", "--"}
+	for _, p := range paths {
+		args = append(args, ":(top)"+p)
+	}
+	cmd := process.Command("git", args...)
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, err
+	}
+
+	var tracked []string
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		if line != "" {
+			tracked = append(tracked, line)
+		}
+	}
+	return tracked, nil
 }
 
 // Add stages all changes
 func Add() error {
-	cmd := exec.Command("git", "add", ".")
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	return cmd.Run()
+	cmd := process.CommandTimeout(process.LongTimeout, "git", "add", ".")
+	return runStreaming(cmd, "git add")
 }
 
 // Commit commits staged changes with the given message
 func Commit(message string) error {
-	cmd := exec.Command("git", "commit", "-m", message)
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	return cmd.Run()
+	cmd := process.CommandTimeout(process.LongTimeout, "git", "commit", "-m", message)
+	return runStreaming(cmd, "git commit")
 }
 
 // PushToMain pushes commits to the remote repository on main branch
 func PushToMain() error {
-	cmd := exec.Command("git", "push")
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	return cmd.Run()
+	cmd := process.CommandTimeout(process.LongTimeout, "git", "push")
+	return runStreaming(cmd, "git push")
 }
 
 // Pull pulls the latest changes from the remote repository
 func Pull(repoDir string) error {
-	cmd := exec.Command("git", "pull")
+	cmd := process.CommandTimeout(process.LongTimeout, "git", "pull")
 	if repoDir != "" {
 		cmd.Dir = repoDir
 	}
 	output, err := cmd.CombinedOutput()
 	if err != nil {
 		// Include the git output in the error message
-		return clierrors.WrapError("git pull failed: "+string(output), err)
+		return commandError("git pull", string(output), err)
+	}
+	return nil
+}
+
+// Stash saves local changes, including untracked files, so the working tree is clean.
+// If repoDir is empty, it uses the current directory.
+func Stash(repoDir, message string) error {
+	cmd := process.Command("git", "stash", "push", "--include-untracked", "-m", message)
+	if repoDir != "" {
+		cmd.Dir = repoDir
+	}
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return commandError("git stash", string(output), err)
+	}
+	return nil
+}
+
+// StashPop reapplies the most recent stash. On conflicts git keeps the stash
+// entry, and the returned error includes git's output listing the conflicts.
+// If repoDir is empty, it uses the current directory.
+func StashPop(repoDir string) error {
+	cmd := process.Command("git", "stash", "pop")
+	if repoDir != "" {
+		cmd.Dir = repoDir
+	}
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return commandError("git stash pop", string(output), err)
 	}
 	return nil
 }
@@ -232,17 +414,14 @@ func Pull(repoDir string) error {
 // Returns whether it's behind, how many commits behind, and any error.
 // Uses a 5-second timeout to avoid blocking if the network is unavailable.
 func IsBehindRemote() (bool, int, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-
 	// Fetch latest from origin
-	fetchCmd := exec.CommandContext(ctx, "git", "fetch", "origin", "main", "--quiet")
+	fetchCmd := process.CommandTimeout(5*time.Second, "git", "fetch", "origin", "main", "--quiet")
 	if err := fetchCmd.Run(); err != nil {
 		return false, 0, err
 	}
 
 	// Count commits local is behind
-	revListCmd := exec.Command("git", "rev-list", "--count", "HEAD..origin/main")
+	revListCmd := process.Command("git", "rev-list", "--count", "HEAD..origin/main")
 	output, err := revListCmd.Output()
 	if err != nil {
 		return false, 0, err
@@ -256,13 +435,55 @@ func IsBehindRemote() (bool, int, error) {
 	return count > 0, count, nil
 }
 
+// HasCommit reports whether the commit exists in the local repository
+func HasCommit(hash string) bool {
+	cmd := process.Command("git", "cat-file", "-e", hash+"^{commit}")
+	return cmd.Run() == nil
+}
+
+// FetchOrigin fetches all branches from origin, giving up after 10 seconds
+func FetchOrigin() error {
+	cmd := process.CommandTimeout(10*time.Second, "git", "fetch", "origin", "--quiet")
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return commandError("git fetch", string(output), err)
+	}
+	return nil
+}
+
+// LogOneline returns one "<short hash> <subject>" line per commit reachable from
+// to but not from from, newest first.
+func LogOneline(from, to string) ([]string, error) {
+	cmd := process.Command("git", "log", "--oneline", "--no-decorate", from+".."+to)
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, err
+	}
+
+	trimmed := strings.TrimSpace(string(output))
+	if trimmed == "" {
+		return nil, nil
+	}
+	return strings.Split(trimmed, "\n"), nil
+}
+
+// DiffStat returns `git diff --stat` output for the changes between two commits
+func DiffStat(from, to string) (string, error) {
+	cmd := process.Command("git", "diff", "--stat", from, to)
+	output, err := cmd.Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(output), "\n"), nil
+}
+
 // GetCurrentGithubUser attempts to retrieve the GitHub username of the current user
 // by checking SSH authentication and git configuration.
 func GetCurrentGithubUser() (string, error) {
 	// 1. Try SSH authentication
 	// ssh -T -o BatchMode=yes -o ConnectTimeout=2 git@github.com
 	// This usually returns exit code 1 on success with "Hi <username>! ..."
-	cmd := exec.Command("ssh", "-T", "-o", "BatchMode=yes", "-o", "ConnectTimeout=2", "git@github.com")
+	cmd := process.CommandTimeout(10*time.Second, "ssh", "-T", "-o", "BatchMode=yes", "-o", "ConnectTimeout=2", "git@github.com")
 	output, _ := cmd.CombinedOutput() // We expect an error (exit code 1), so we ignore it and parse output
 
 	outputStr := string(output)
@@ -274,14 +495,14 @@ func GetCurrentGithubUser() (string, error) {
 	}
 
 	// 2. Check git config for github.user
-	cmd = exec.Command("git", "config", "--get", "github.user")
+	cmd = process.Command("git", "config", "--get", "github.user")
 	output, err := cmd.Output()
 	if err == nil && len(output) > 0 {
 		return strings.TrimSpace(string(output)), nil
 	}
 
 	// 3. Check git config user.email for GitHub noreply address
-	cmd = exec.Command("git", "config", "--get", "user.email")
+	cmd = process.Command("git", "config", "--get", "user.email")
 	output, err = cmd.Output()
 	if err == nil {
 		email := strings.TrimSpace(string(output))
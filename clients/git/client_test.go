@@ -0,0 +1,149 @@
+package git
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/major-technology/cli/clients/process"
+)
+
+func TestParseStatus(t *testing.T) {
+	output := []byte(" M src/app.tsx\x00R  new name.ts\x00old name.ts\x00?? .env\x00A  lib/util.ts\x00")
+
+	got := parseStatus(output)
+	want := []FileStatus{
+		{State: " M", Path: "src/app.tsx"},
+		{State: "R ", Path: "new name.ts", OrigPath: "old name.ts"},
+		{State: "??", Path: ".env"},
+		{State: "A ", Path: "lib/util.ts"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("parseStatus() = %+v, want %+v", got, want)
+	}
+}
+
+func TestParseStatusEmpty(t *testing.T) {
+	if got := parseStatus(nil); len(got) != 0 {
+		t.Fatalf("parseStatus(nil) = %+v, want empty", got)
+	}
+}
+
+func TestParseRemoteURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		url     string
+		want    *RemoteInfo
+		wantErr bool
+	}{
+		{name: "ssh", url: "git@github.com:acme/web-app.git", want: &RemoteInfo{Owner: "acme", Repo: "web-app"}},
+		{name: "ssh without .git", url: "git@github.com:acme/web-app", want: &RemoteInfo{Owner: "acme", Repo: "web-app"}},
+		{name: "https", url: "https://github.com/acme/web-app.git", want: &RemoteInfo{Owner: "acme", Repo: "web-app"}},
+		{name: "https without .git", url: "https://github.com/acme/web-app", want: &RemoteInfo{Owner: "acme", Repo: "web-app"}},
+		{name: "surrounding whitespace", url: "  https://github.com/acme/web-app.git\n", want: &RemoteInfo{Owner: "acme", Repo: "web-app"}},
+		{name: "dots in repo name", url: "git@github.com:acme/site.v2.git", want: &RemoteInfo{Owner: "acme", Repo: "site.v2"}},
+		{name: "uppercase owner and repo", url: "https://github.com/Acme/Web-App.git", want: &RemoteInfo{Owner: "Acme", Repo: "Web-App"}},
+
+		// Not supported yet; these document the current behavior
+		{name: "trailing slash", url: "https://github.com/acme/web-app/", wantErr: true},
+		{name: "uppercase host", url: "https://GitHub.com/acme/web-app.git", wantErr: true},
+		{name: "ssh scheme", url: "ssh://git@github.com/acme/web-app.git", wantErr: true},
+		{name: "ssh host alias", url: "git@github-work:acme/web-app.git", wantErr: true},
+		{name: "https with credentials", url: "https://user@github.com/acme/web-app.git", wantErr: true},
+		{name: "other host", url: "git@gitlab.com:acme/web-app.git", wantErr: true},
+
+		{name: "empty", url: "", wantErr: true},
+		{name: "missing repo", url: "https://github.com/acme", wantErr: true},
+		{name: "extra path segment", url: "https://github.com/acme/web-app/tree/main", wantErr: true},
+		{name: "not a url", url: "web-app", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseRemoteURL(tt.url)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseRemoteURL(%q) = %+v, want error", tt.url, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseRemoteURL(%q) error: %v", tt.url, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("ParseRemoteURL(%q) = %+v, want %+v", tt.url, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunStreamingIncludesStderr(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skipf("git not available: %v", err)
+	}
+
+	// Not a repository, so git fails with a message on stderr
+	cmd := process.Command("git", "remote", "remove", "origin")
+	cmd.Dir = t.TempDir()
+	cmd.Env = append(os.Environ(), "GIT_CEILING_DIRECTORIES="+filepath.Dir(cmd.Dir))
+
+	err := runStreaming(cmd, "git remote remove")
+	if err == nil {
+		t.Fatal("expected an error outside a repository")
+	}
+
+	var msgs []string
+	for e := err; e != nil; e = errors.Unwrap(e) {
+		msgs = append(msgs, e.Error())
+	}
+	if !strings.Contains(strings.ToLower(strings.Join(msgs, "\n")), "not a git repository") {
+		t.Errorf("error chain %q doesn't include git's stderr", msgs)
+	}
+}
+
+func TestResetHistoryWithoutIdentity(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skipf("git not available: %v", err)
+	}
+
+	// Like a fresh CI runner: no identity in the environment or git config
+	for _, key := range []string{"GIT_AUTHOR_NAME", "GIT_AUTHOR_EMAIL", "GIT_COMMITTER_NAME", "GIT_COMMITTER_EMAIL", "EMAIL"} {
+		t.Setenv(key, "")
+		os.Unsetenv(key)
+	}
+	globalConfig := filepath.Join(t.TempDir(), "gitconfig")
+	if err := os.WriteFile(globalConfig, []byte("[user]\n\tuseConfigOnly = true\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("GIT_CONFIG_GLOBAL", globalConfig)
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+
+	dir := t.TempDir()
+	if out, err := exec.Command("git", "-C", dir, "init", "-q").CombinedOutput(); err != nil {
+		t.Fatalf("git init: %v\n%s", err, out)
+	}
+	if hasIdentity(dir) {
+		t.Fatal("hasIdentity = true, want false with no identity configured")
+	}
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("template\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if out, err := exec.Command("git", "-C", dir, "add", "README.md").CombinedOutput(); err != nil {
+		t.Fatalf("git add: %v\n%s", err, out)
+	}
+
+	if err := ResetHistory(dir, "Initial commit"); err != nil {
+		t.Fatalf("ResetHistory: %v", err)
+	}
+	out, err := exec.Command("git", "-C", dir, "log", "--format=%an|%s", "main").Output()
+	if err != nil {
+		t.Fatalf("git log: %v", err)
+	}
+	if got := strings.TrimSpace(string(out)); got != "Major CLI|Initial commit" {
+		t.Errorf("main history = %q, want a single commit by Major CLI", got)
+	}
+}
@@ -0,0 +1,69 @@
+package process
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// shutdownGrace is how long a process group gets to exit after a forwarded signal
+// before it is killed
+var shutdownGrace = 10 * time.Second
+
+// RunGroup runs a long-lived command such as a dev server in its own process group
+// until it exits. SIGINT and SIGTERM sent to the CLI are forwarded to the whole group,
+// which is killed if it hasn't exited within shutdownGrace. Once the command exits,
+// anything it left running in the group is killed, so no orphaned processes remain,
+// and the terminal is handed back to the CLI. interrupted reports whether the command
+// was stopped by a signal.
+func RunGroup(cmd *exec.Cmd) (interrupted bool, err error) {
+	restoreTerminal := setProcessGroup(cmd)
+	defer restoreTerminal()
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signals)
+
+	if err := cmd.Start(); err != nil {
+		return false, err
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- cmd.Wait()
+	}()
+
+	var kill <-chan time.Time
+	for {
+		select {
+		case sig := <-signals:
+			interrupted = true
+			signalGroup(cmd, sig)
+			if kill == nil {
+				kill = time.After(shutdownGrace)
+			}
+		case <-kill:
+			signalGroup(cmd, os.Kill)
+		case err := <-done:
+			signalGroup(cmd, os.Kill)
+			return interrupted || exitedFromSignal(err), err
+		}
+	}
+}
+
+// exitedFromSignal reports whether err is the exit of a process stopped by a
+// signal, either directly or by a shell-style 128+n exit code for SIGINT or SIGTERM
+func exitedFromSignal(err error) bool {
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) {
+		return false
+	}
+	if status, ok := exitErr.Sys().(syscall.WaitStatus); ok && status.Signaled() {
+		return true
+	}
+	code := exitErr.ExitCode()
+	return code == 130 || code == 143
+}
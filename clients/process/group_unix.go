@@ -0,0 +1,49 @@
+//go:build unix
+
+package process
+
+import (
+	"os"
+	"os/exec"
+	"os/signal"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+// setProcessGroup starts cmd in a new process group. When the CLI owns the terminal,
+// the group is made the terminal's foreground group, so Ctrl+C and keyboard input
+// reach the command and its children directly. The returned restore gives the
+// terminal back to the CLI's group and must be called once cmd has exited.
+func setProcessGroup(cmd *exec.Cmd) (restore func()) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+
+	// Only take the terminal if it's ours, e.g. not when major runs in the background
+	fd := int(os.Stdin.Fd())
+	foreground, err := unix.IoctlGetInt(fd, unix.TIOCGPGRP)
+	if err != nil || foreground != unix.Getpgrp() {
+		return func() {}
+	}
+
+	cmd.SysProcAttr.Foreground = true
+	cmd.SysProcAttr.Ctty = fd
+	return func() {
+		// A background group setting the foreground group gets SIGTTOU, which stops it
+		signal.Ignore(syscall.SIGTTOU)
+		defer signal.Reset(syscall.SIGTTOU)
+		unix.IoctlSetPointerInt(fd, unix.TIOCSPGRP, foreground)
+	}
+}
+
+// signalGroup sends sig to every process in cmd's process group. Errors are
+// ignored, since the group may already be gone.
+func signalGroup(cmd *exec.Cmd, sig os.Signal) {
+	if cmd.Process == nil {
+		return
+	}
+	if s, ok := sig.(syscall.Signal); ok {
+		syscall.Kill(-cmd.Process.Pid, s)
+		return
+	}
+	cmd.Process.Signal(sig)
+}
@@ -0,0 +1,43 @@
+//go:build unix
+
+package process
+
+import (
+	"os/exec"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestRunGroupForwardsSignals(t *testing.T) {
+	requireCommand(t, "sh")
+
+	// sh and its background sleep share the group, so both must stop
+	cmd := exec.Command("sh", "-c", "sleep 30 & wait")
+
+	go func() {
+		time.Sleep(200 * time.Millisecond)
+		syscall.Kill(syscall.Getpid(), syscall.SIGTERM)
+	}()
+
+	start := time.Now()
+	interrupted, _ := RunGroup(cmd)
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("RunGroup took %s, want it to stop after the forwarded signal", elapsed)
+	}
+	if !interrupted {
+		t.Error("interrupted = false, want true after SIGTERM")
+	}
+}
+
+func TestRunGroupCleanExit(t *testing.T) {
+	requireCommand(t, "true")
+
+	interrupted, err := RunGroup(exec.Command("true"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if interrupted {
+		t.Error("interrupted = true, want false for a clean exit")
+	}
+}
@@ -0,0 +1,23 @@
+//go:build windows
+
+package process
+
+import (
+	"os"
+	"os/exec"
+)
+
+// setProcessGroup is a no-op on Windows, where Ctrl+C already reaches every
+// process attached to the console
+func setProcessGroup(cmd *exec.Cmd) (restore func()) {
+	return func() {}
+}
+
+// signalGroup stops cmd. Windows can't deliver other signals to another
+// process, so any signal kills it.
+func signalGroup(cmd *exec.Cmd, sig os.Signal) {
+	if cmd.Process == nil {
+		return
+	}
+	cmd.Process.Kill()
+}
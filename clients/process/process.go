@@ -0,0 +1,126 @@
+// Package process runs subprocesses with a deadline, so a hung git, ssh or
+// pnpm reports an error instead of freezing the CLI.
+package process
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	clierrors "github.com/major-technology/cli/errors"
+)
+
+var (
+	// DefaultTimeout bounds quick, local commands such as git status or node --version.
+	// Override it with the command_timeout config key or MAJOR_COMMAND_TIMEOUT.
+	DefaultTimeout = 30 * time.Second
+
+	// LongTimeout bounds commands that transfer data or install dependencies, such as
+	// git clone, git push or pnpm install. Override it with the long_command_timeout
+	// config key or MAJOR_LONG_COMMAND_TIMEOUT.
+	LongTimeout = 10 * time.Minute
+
+	// waitDelay is how long to wait for output pipes to close after the process
+	// is killed, since children such as ssh may keep them open
+	waitDelay = 5 * time.Second
+)
+
+// SetTimeouts replaces DefaultTimeout and LongTimeout with the configured values.
+// A zero value keeps the built-in timeout. It must be called before any command
+// is built.
+func SetTimeouts(defaultTimeout, longTimeout time.Duration) {
+	if defaultTimeout > 0 {
+		DefaultTimeout = defaultTimeout
+	}
+	if longTimeout > 0 {
+		LongTimeout = longTimeout
+	}
+}
+
+// Cmd is an exec.Cmd that is killed once its timeout expires. Run, Output,
+// CombinedOutput and Wait release the timeout and report an expired one as a
+// CLIError.
+type Cmd struct {
+	*exec.Cmd
+	ctx     context.Context
+	cancel  context.CancelFunc
+	timeout time.Duration
+}
+
+// Command returns a Cmd that runs name with args under DefaultTimeout
+func Command(name string, args ...string) *Cmd {
+	return CommandTimeout(DefaultTimeout, name, args...)
+}
+
+// CommandTimeout returns a Cmd that runs name with args under timeout
+func CommandTimeout(timeout time.Duration, name string, args ...string) *Cmd {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.WaitDelay = waitDelay
+	return &Cmd{Cmd: cmd, ctx: ctx, cancel: cancel, timeout: timeout}
+}
+
+// Run starts the command and waits for it to finish
+func (c *Cmd) Run() error {
+	defer c.cancel()
+	return c.check(c.Cmd.Run())
+}
+
+// Output runs the command and returns its standard output
+func (c *Cmd) Output() ([]byte, error) {
+	defer c.cancel()
+	output, err := c.Cmd.Output()
+	return output, c.check(err)
+}
+
+// CombinedOutput runs the command and returns its standard output and standard error
+func (c *Cmd) CombinedOutput() ([]byte, error) {
+	defer c.cancel()
+	output, err := c.Cmd.CombinedOutput()
+	return output, c.check(err)
+}
+
+// Start starts the command without waiting for it; call Wait to release it
+func (c *Cmd) Start() error {
+	err := c.Cmd.Start()
+	if err != nil {
+		c.cancel()
+	}
+	return err
+}
+
+// Wait waits for a command started with Start to finish
+func (c *Cmd) Wait() error {
+	defer c.cancel()
+	return c.check(c.Cmd.Wait())
+}
+
+// check replaces err with a CLIError if the command was killed for running past its timeout
+func (c *Cmd) check(err error) error {
+	if err == nil || !errors.Is(c.ctx.Err(), context.DeadlineExceeded) {
+		return err
+	}
+
+	envVar := "MAJOR_COMMAND_TIMEOUT"
+	if c.timeout == LongTimeout {
+		envVar = "MAJOR_LONG_COMMAND_TIMEOUT"
+	}
+	return &clierrors.CLIError{
+		Title:      fmt.Sprintf("'%s' timed out after %s", c.name(), c.timeout),
+		Suggestion: fmt.Sprintf("Check your network connection and try again. On a slow connection, allow more time with %s, e.g. %s=%s.", envVar, envVar, 2*c.timeout),
+		Err:        err,
+	}
+}
+
+// name returns the program and its first argument, e.g. "git clone"
+func (c *Cmd) name() string {
+	parts := []string{filepath.Base(c.Path)}
+	if len(c.Args) > 1 {
+		parts = append(parts, c.Args[1])
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,86 @@
+package process
+
+import (
+	"errors"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	clierrors "github.com/major-technology/cli/errors"
+)
+
+func requireCommand(t *testing.T, name string) {
+	t.Helper()
+	if _, err := exec.LookPath(name); err != nil {
+		t.Skipf("%s not available: %v", name, err)
+	}
+}
+
+func TestCommandOutput(t *testing.T) {
+	requireCommand(t, "echo")
+
+	output, err := Command("echo", "hello").Output()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.TrimSpace(string(output)) != "hello" {
+		t.Errorf("output = %q, want hello", output)
+	}
+}
+
+func TestCommandFailureIsNotATimeout(t *testing.T) {
+	requireCommand(t, "false")
+
+	err := Command("false").Run()
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) {
+		t.Fatalf("error = %v, want *exec.ExitError", err)
+	}
+	var cliErr *clierrors.CLIError
+	if errors.As(err, &cliErr) {
+		t.Errorf("error = %v, want a plain exit error", err)
+	}
+}
+
+func TestCommandTimeout(t *testing.T) {
+	requireCommand(t, "sleep")
+
+	start := time.Now()
+	err := CommandTimeout(50*time.Millisecond, "sleep", "10").Run()
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Run took %s, want it killed at the timeout", elapsed)
+	}
+
+	var cliErr *clierrors.CLIError
+	if !errors.As(err, &cliErr) {
+		t.Fatalf("error = %v, want *CLIError", err)
+	}
+	if !strings.Contains(cliErr.Title, "'sleep 10' timed out") {
+		t.Errorf("Title = %q, want it to name the command", cliErr.Title)
+	}
+	if !strings.Contains(cliErr.Suggestion, "MAJOR_COMMAND_TIMEOUT") {
+		t.Errorf("Suggestion = %q, want it to name the setting that raises the limit", cliErr.Suggestion)
+	}
+}
+
+func TestSetTimeouts(t *testing.T) {
+	prevDefault, prevLong := DefaultTimeout, LongTimeout
+	t.Cleanup(func() { DefaultTimeout, LongTimeout = prevDefault, prevLong })
+
+	// Unset values keep the built-in timeouts
+	SetTimeouts(0, 0)
+	if DefaultTimeout != prevDefault || LongTimeout != prevLong {
+		t.Errorf("SetTimeouts(0, 0) changed the timeouts to %s, %s", DefaultTimeout, LongTimeout)
+	}
+
+	SetTimeouts(time.Minute, time.Hour)
+	if DefaultTimeout != time.Minute || LongTimeout != time.Hour {
+		t.Errorf("timeouts = %s, %s, want 1m, 1h", DefaultTimeout, LongTimeout)
+	}
+	cmd := Command("true")
+	defer cmd.cancel()
+	if cmd.timeout != time.Minute {
+		t.Errorf("Command timeout = %s, want the configured 1m", cmd.timeout)
+	}
+}
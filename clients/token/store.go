@@ -0,0 +1,244 @@
+package token
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/zalando/go-keyring"
+)
+
+// Credential store names accepted by SetCredentialStore (config key credential_store)
+const (
+	// CredentialStoreAuto uses the system keyring and falls back to the file store when it's unavailable
+	CredentialStoreAuto = "auto"
+	// CredentialStoreKeyring always uses the system keyring
+	CredentialStoreKeyring = "keyring"
+	// CredentialStoreFile always uses the encrypted file under ~/.major/credentials
+	CredentialStoreFile = "file"
+)
+
+// credentialStore persists credentials by key. Get returns keyring.ErrNotFound
+// for missing keys regardless of the backend. Name describes the backend in errors.
+type credentialStore interface {
+	Name() string
+	Get(key string) (string, error)
+	Set(key, value string) error
+	Delete(key string) error
+}
+
+var (
+	storeMode = CredentialStoreAuto
+	storeOnce sync.Once
+	store     credentialStore
+)
+
+// SetCredentialStore selects the credential backend. It must be called before
+// any credential is read or written; unknown values behave like "auto".
+func SetCredentialStore(mode string) {
+	storeMode = strings.ToLower(strings.TrimSpace(mode))
+}
+
+// getStore resolves the credential backend once per process. In auto mode the
+// keyring is probed, and any failure other than a missing entry (e.g. no
+// Secret Service on headless Linux) switches to the file store.
+func getStore() credentialStore {
+	storeOnce.Do(func() {
+		switch storeMode {
+		case CredentialStoreKeyring:
+			store = keyringStore{}
+		case CredentialStoreFile:
+			store = newFileStore()
+		default:
+			if _, err := keyring.Get(keyringService, keyringUser); err != nil && !errors.Is(err, keyring.ErrNotFound) {
+				store = newFileStore()
+			} else {
+				store = keyringStore{}
+			}
+		}
+	})
+	return store
+}
+
+// keyringStore stores credentials in the system keyring
+type keyringStore struct{}
+
+func (keyringStore) Name() string {
+	return "keyring"
+}
+
+func (keyringStore) Get(key string) (string, error) {
+	return keyring.Get(keyringService, key)
+}
+
+func (keyringStore) Set(key, value string) error {
+	return keyring.Set(keyringService, key, value)
+}
+
+func (keyringStore) Delete(key string) error {
+	return keyring.Delete(keyringService, key)
+}
+
+// fileStore stores credentials as AES-GCM encrypted JSON in a 0600 file. The key
+// is derived from the machine ID and home directory, so it mostly guards against
+// the file being copied elsewhere; file permissions are the primary protection.
+type fileStore struct {
+	path string
+	mu   sync.Mutex
+}
+
+func newFileStore() *fileStore {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		homeDir = "."
+	}
+	return &fileStore{path: filepath.Join(homeDir, ".major", "credentials")}
+}
+
+func (s *fileStore) Name() string {
+	return "credentials file"
+}
+
+func (s *fileStore) Get(key string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	values, err := s.load()
+	if err != nil {
+		return "", err
+	}
+	value, ok := values[key]
+	if !ok {
+		return "", keyring.ErrNotFound
+	}
+	return value, nil
+}
+
+func (s *fileStore) Set(key, value string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	values, err := s.load()
+	if err != nil {
+		return err
+	}
+	values[key] = value
+	return s.save(values)
+}
+
+func (s *fileStore) Delete(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	values, err := s.load()
+	if err != nil {
+		return err
+	}
+	if _, ok := values[key]; !ok {
+		return keyring.ErrNotFound
+	}
+	delete(values, key)
+	return s.save(values)
+}
+
+// load reads and decrypts the credentials file. A missing file is an empty store.
+func (s *fileStore) load() (map[string]string, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return map[string]string{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read credentials file: %w", err)
+	}
+
+	gcm, err := s.cipher()
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < gcm.NonceSize() {
+		return nil, fmt.Errorf("credentials file is corrupt")
+	}
+	nonce, ciphertext := data[:gcm.NonceSize()], data[gcm.NonceSize():]
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt credentials file: %w", err)
+	}
+
+	values := map[string]string{}
+	if err := json.Unmarshal(plaintext, &values); err != nil {
+		return nil, fmt.Errorf("failed to parse credentials file: %w", err)
+	}
+	return values, nil
+}
+
+// save encrypts values and writes them to the credentials file with 0600 perms.
+// It writes a temp file and renames it into place, so an interrupted write
+// leaves the previous credentials intact.
+func (s *fileStore) save(values map[string]string) error {
+	plaintext, err := json.Marshal(values)
+	if err != nil {
+		return fmt.Errorf("failed to marshal credentials: %w", err)
+	}
+
+	gcm, err := s.cipher()
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	data := gcm.Seal(nonce, nonce, plaintext, nil)
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0700); err != nil {
+		return fmt.Errorf("failed to create credentials directory: %w", err)
+	}
+
+	// CreateTemp always uses 0600, which is the mode the credentials file needs
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), ".credentials.tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp credentials file: %w", err)
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath)
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write credentials file: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to sync credentials file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to close credentials file: %w", err)
+	}
+	if err := os.Rename(tmpPath, s.path); err != nil {
+		return fmt.Errorf("failed to replace credentials file: %w", err)
+	}
+	return nil
+}
+
+func (s *fileStore) cipher() (cipher.AEAD, error) {
+	machineID, _ := os.ReadFile("/etc/machine-id")
+	if len(machineID) == 0 {
+		hostname, _ := os.Hostname()
+		machineID = []byte(hostname)
+	}
+	key := sha256.Sum256([]byte(keyringService + "\x00" + strings.TrimSpace(string(machineID)) + "\x00" + filepath.Dir(s.path)))
+
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
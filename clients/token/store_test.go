@@ -0,0 +1,95 @@
+package token
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/zalando/go-keyring"
+)
+
+func TestFileStoreRoundTrip(t *testing.T) {
+	s := &fileStore{path: filepath.Join(t.TempDir(), ".major", "credentials")}
+
+	if _, err := s.Get(keyringUser); !errors.Is(err, keyring.ErrNotFound) {
+		t.Fatalf("Get on empty store: err = %v, want keyring.ErrNotFound", err)
+	}
+
+	if err := s.Set(keyringUser, "secret-token"); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	got, err := s.Get(keyringUser)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got != "secret-token" {
+		t.Fatalf("Get = %q, want %q", got, "secret-token")
+	}
+
+	info, err := os.Stat(s.path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0600 {
+		t.Errorf("perm = %o, want 600", perm)
+	}
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(data, []byte("secret-token")) {
+		t.Fatal("credentials file contains the plaintext token")
+	}
+	entries, err := os.ReadDir(filepath.Dir(s.path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("credentials directory has %d entries, want only the credentials file", len(entries))
+	}
+
+	if err := s.Delete(keyringUser); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := s.Get(keyringUser); !errors.Is(err, keyring.ErrNotFound) {
+		t.Fatalf("Get after Delete: err = %v, want keyring.ErrNotFound", err)
+	}
+}
+
+func TestDeleteMissingCredentials(t *testing.T) {
+	storeOnce.Do(func() {})
+	prev := store
+	store = &fileStore{path: filepath.Join(t.TempDir(), ".major", "credentials")}
+	t.Cleanup(func() { store = prev })
+
+	if err := DeleteToken(); err != nil {
+		t.Errorf("DeleteToken on empty store: %v", err)
+	}
+	if err := DeleteDefaultOrg(); err != nil {
+		t.Errorf("DeleteDefaultOrg on empty store: %v", err)
+	}
+	if err := DeleteGithubUsername(); err != nil {
+		t.Errorf("DeleteGithubUsername on empty store: %v", err)
+	}
+}
+
+func TestFileStoreErrorsNameTheBackend(t *testing.T) {
+	storeOnce.Do(func() {})
+	prev := store
+	path := filepath.Join(t.TempDir(), "credentials")
+	store = &fileStore{path: path}
+	t.Cleanup(func() { store = prev })
+	t.Setenv(TokenEnvVar, "")
+
+	if err := os.WriteFile(path, []byte("garbage"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := GetToken()
+	if err == nil || !strings.Contains(err.Error(), "credentials file") {
+		t.Fatalf("GetToken with a corrupt credentials file = %v, want an error naming the credentials file", err)
+	}
+}
@@ -1,6 +1,8 @@
 package token
 
 import (
+	"os"
+
 	clierrors "github.com/major-technology/cli/errors"
 	"github.com/zalando/go-keyring"
 )
@@ -16,79 +18,87 @@ const (
 	keyringOrgName = "default-org-name"
 	// keyringGithubUsername is the key for storing the GitHub username in the system keyring
 	keyringGithubUsername = "github-username"
+
+	// TokenEnvVar is the environment variable that supplies a token without the keyring, for CI and containers
+	TokenEnvVar = "MAJOR_TOKEN"
 )
 
 // storeToken saves the access token to the system keyring
 func StoreToken(token string) error {
-	err := keyring.Set(keyringService, keyringUser, token)
+	err := getStore().Set(keyringUser, token)
 	if err != nil {
-		return clierrors.WrapError("failed to store token in keyring", err)
+		return clierrors.WrapError("failed to store token in the "+getStore().Name(), err)
 	}
 	return nil
 }
 
-// getToken retrieves the access token from the system keyring
+// getToken retrieves the access token from MAJOR_TOKEN if set, otherwise from the system keyring
 func GetToken() (string, error) {
-	token, err := keyring.Get(keyringService, keyringUser)
+	if token := os.Getenv(TokenEnvVar); token != "" {
+		return token, nil
+	}
+
+	token, err := getStore().Get(keyringUser)
 	if err != nil {
-		return "", clierrors.WrapError("failed to get token from keyring", err)
+		return "", clierrors.WrapError("failed to get token from the "+getStore().Name(), err)
 	}
 	return token, nil
 }
 
-// deleteToken removes the access token from the system keyring
+// deleteToken removes the access token from the system keyring. A missing token is not an error.
 func DeleteToken() error {
-	err := keyring.Delete(keyringService, keyringUser)
-	if err != nil {
-		return clierrors.WrapError("failed to delete token from keyring", err)
+	err := getStore().Delete(keyringUser)
+	if err != nil && err != keyring.ErrNotFound {
+		return clierrors.WrapError("failed to delete token from the "+getStore().Name(), err)
 	}
 	return nil
 }
 
 // StoreDefaultOrg saves the default organization ID to the system keyring
 func StoreDefaultOrg(orgID string, orgName string) error {
-	err := keyring.Set(keyringService, keyringOrgUser, orgID)
+	err := getStore().Set(keyringOrgUser, orgID)
 	if err != nil {
-		return clierrors.WrapError("failed to store default org in keyring", err)
+		return clierrors.WrapError("failed to store default org in the "+getStore().Name(), err)
 	}
-	err = keyring.Set(keyringService, keyringOrgName, orgName)
+	err = getStore().Set(keyringOrgName, orgName)
 	if err != nil {
-		return clierrors.WrapError("failed to store default org name in keyring", err)
+		return clierrors.WrapError("failed to store default org name in the "+getStore().Name(), err)
 	}
 	return nil
 }
 
 // GetDefaultOrg retrieves the default organization ID from the system keyring
 func GetDefaultOrg() (string, string, error) {
-	orgID, err := keyring.Get(keyringService, keyringOrgUser)
+	orgID, err := getStore().Get(keyringOrgUser)
 	if err != nil {
-		return "", "", clierrors.WrapError("failed to get default org from keyring", err)
+		return "", "", clierrors.WrapError("failed to get default org from the "+getStore().Name(), err)
 	}
-	orgName, err := keyring.Get(keyringService, keyringOrgName)
+	orgName, err := getStore().Get(keyringOrgName)
 	if err != nil {
-		return "", "", clierrors.WrapError("failed to get default org name from keyring", err)
+		return "", "", clierrors.WrapError("failed to get default org name from the "+getStore().Name(), err)
 	}
 	return orgID, orgName, nil
 }
 
-// DeleteDefaultOrg removes the default organization ID from the system keyring
+// DeleteDefaultOrg removes the default organization ID from the system keyring.
+// A missing organization is not an error.
 func DeleteDefaultOrg() error {
-	err := keyring.Delete(keyringService, keyringOrgUser)
-	if err != nil {
-		return clierrors.WrapError("failed to delete default org from keyring", err)
+	err := getStore().Delete(keyringOrgUser)
+	if err != nil && err != keyring.ErrNotFound {
+		return clierrors.WrapError("failed to delete default org from the "+getStore().Name(), err)
 	}
-	err = keyring.Delete(keyringService, keyringOrgName)
-	if err != nil {
-		return clierrors.WrapError("failed to delete default org name from keyring", err)
+	err = getStore().Delete(keyringOrgName)
+	if err != nil && err != keyring.ErrNotFound {
+		return clierrors.WrapError("failed to delete default org name from the "+getStore().Name(), err)
 	}
 	return nil
 }
 
 // StoreGithubUsername saves the GitHub username to the system keyring
 func StoreGithubUsername(username string) error {
-	err := keyring.Set(keyringService, keyringGithubUsername, username)
+	err := getStore().Set(keyringGithubUsername, username)
 	if err != nil {
-		return clierrors.WrapError("failed to store GitHub username in keyring", err)
+		return clierrors.WrapError("failed to store GitHub username in the "+getStore().Name(), err)
 	}
 	return nil
 }
@@ -96,26 +106,26 @@ func StoreGithubUsername(username string) error {
 // GetGithubUsername retrieves the GitHub username from the system keyring
 // Returns empty string and nil error if not found
 func GetGithubUsername() (string, error) {
-	username, err := keyring.Get(keyringService, keyringGithubUsername)
+	username, err := getStore().Get(keyringGithubUsername)
 	if err != nil {
 		// Check if it's a "not found" error
 		if err == keyring.ErrNotFound {
 			return "", nil
 		}
-		return "", clierrors.WrapError("failed to get GitHub username from keyring", err)
+		return "", clierrors.WrapError("failed to get GitHub username from the "+getStore().Name(), err)
 	}
 	return username, nil
 }
 
 // DeleteGithubUsername removes the GitHub username from the system keyring
 func DeleteGithubUsername() error {
-	err := keyring.Delete(keyringService, keyringGithubUsername)
+	err := getStore().Delete(keyringGithubUsername)
 	if err != nil {
 		// Ignore "not found" errors
 		if err == keyring.ErrNotFound {
 			return nil
 		}
-		return clierrors.WrapError("failed to delete GitHub username from keyring", err)
+		return clierrors.WrapError("failed to delete GitHub username from the "+getStore().Name(), err)
 	}
 	return nil
 }
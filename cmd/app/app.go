@@ -26,12 +26,15 @@ var Cmd = &cobra.Command{
 func init() {
 	// Add app subcommands
 	Cmd.AddCommand(cloneCmd)
+	Cmd.AddCommand(collaboratorsCmd)
 	Cmd.AddCommand(configureCmd)
 	Cmd.AddCommand(createCmd)
 	Cmd.AddCommand(deployCmd)
 	Cmd.AddCommand(deployStatusCmd)
+	Cmd.AddCommand(diffCmd)
 	Cmd.AddCommand(infoCmd)
 	Cmd.AddCommand(listCmd)
 	Cmd.AddCommand(logsCmd)
+	Cmd.AddCommand(resourcesCmd)
 	Cmd.AddCommand(startCmd)
 }
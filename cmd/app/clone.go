@@ -6,43 +6,88 @@ import (
 	"path/filepath"
 	"regexp"
 	"strings"
+	"unicode"
 
 	"github.com/charmbracelet/huh"
 	"github.com/major-technology/cli/clients/api"
+	"github.com/major-technology/cli/clients/git"
 	"github.com/major-technology/cli/clients/token"
 	"github.com/major-technology/cli/errors"
+	"github.com/major-technology/cli/middleware"
 	"github.com/major-technology/cli/singletons"
 	"github.com/major-technology/cli/utils"
 	"github.com/spf13/cobra"
+	"golang.org/x/text/unicode/norm"
 )
 
 // Flag variables for non-interactive mode
 var flagAppID string
 var flagGithubUser string
+var flagStash bool
+var flagDirectory string
+var flagCloneNoEnv bool
+var flagCloneNoResources bool
+var flagCloneRun bool
 
 // cloneCmd represents the app clone command
 var cloneCmd = &cobra.Command{
-	Use:   "clone",
+	Use:   "clone [app-id-or-name]",
 	Short: "Clone an application repository",
 	Long: `Select and clone an application repository from your organization, then generate env and resources.
 
 By default, this command runs interactively, prompting you to select an application.
-You can also provide the application ID via flag for non-interactive usage:
+You can also name the application, by ID or name, for non-interactive usage:
 
+  major app clone my-app
   major app clone --app-id "your-application-id"
 
+If the application's directory already exists, it is pulled instead of cloned.
+
+Use --no-env to clone without writing secrets (e.g. for read-only review), and
+--no-resources to skip RESOURCES.md.
+
+Use --run to install dependencies and start the development server once the
+clone is set up, as 'major app start' would.
+
 GitHub username is auto-detected from your SSH configuration.`,
+	Args: cobra.MaximumNArgs(1),
+	PreRunE: middleware.Compose(
+		middleware.CheckGitInstalled,
+	),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return runClone(cmd)
+		return runClone(cmd, args)
 	},
 }
 
 func init() {
 	cloneCmd.Flags().StringVar(&flagAppID, "app-id", "", "Application ID to clone (skips interactive prompt)")
 	cloneCmd.Flags().StringVar(&flagGithubUser, "github-user", "", "GitHub username for repository access (for non-interactive mode)")
+	cloneCmd.Flags().StringVar(&flagDirectory, "directory", "", "Clone into (or pull) this directory instead of one named after the application")
+	cloneCmd.Flags().BoolVar(&flagStash, "stash", false, "Stash local changes before pulling into an existing directory, then reapply them")
+	cloneCmd.Flags().StringSliceVar(&flagEditors, "editors", []string{"claude"}, "AI editors to write MCP configs for (claude, cursor, vscode)")
+	cloneCmd.Flags().BoolVar(&flagNoGitignore, "no-gitignore", false, "Don't add generated MCP configs to .gitignore")
+	cloneCmd.Flags().StringVar(&flagGitProtocol, "git-protocol", utils.GitProtocolAuto, "Git transport: ssh, https, or auto to use SSH when it works with GitHub")
+	cloneCmd.Flags().BoolVar(&flagCloneNoEnv, "no-env", false, "Don't generate .env or MCP configs, so no secrets are written")
+	cloneCmd.Flags().BoolVar(&flagCloneNoResources, "no-resources", false, "Don't generate RESOURCES.md")
+	cloneCmd.Flags().BoolVar(&flagCloneRun, "run", false, "Install dependencies and start the development server after cloning")
 }
 
-func runClone(cmd *cobra.Command) error {
+func runClone(cmd *cobra.Command, args []string) error {
+	appRef := flagAppID
+	if len(args) == 1 {
+		if flagAppID != "" && flagAppID != args[0] {
+			return fmt.Errorf("pass the application either as an argument or with --app-id, not both")
+		}
+		appRef = args[0]
+	}
+
+	if _, err := utils.ParseMcpEditors(flagEditors); err != nil {
+		return errors.WrapError("invalid --editors value", err)
+	}
+	if err := utils.ValidateGitProtocol(flagGitProtocol); err != nil {
+		return err
+	}
+
 	// Get the default organization ID from keyring
 	orgID, orgName, err := token.GetDefaultOrg()
 	if err != nil {
@@ -67,17 +112,10 @@ func runClone(cmd *cobra.Command) error {
 	// Select application: use flag if provided, otherwise prompt interactively
 	var selectedApp *api.ApplicationItem
 
-	if flagAppID != "" {
-		// Find the application by ID
-		for i, app := range appsResp.Applications {
-			if app.ID == flagAppID {
-				selectedApp = &appsResp.Applications[i]
-				break
-			}
-		}
-
-		if selectedApp == nil {
-			return fmt.Errorf("application with ID '%s' not found in your organization", flagAppID)
+	if appRef != "" {
+		selectedApp, err = findApplication(appsResp.Applications, appRef)
+		if err != nil {
+			return err
 		}
 	} else {
 		// Let user select application interactively
@@ -90,22 +128,38 @@ func runClone(cmd *cobra.Command) error {
 
 	cmd.Printf("Selected application: %s\n", selectedApp.Name)
 
-	// Determine the desired directory name (based on app name)
+	// Determine the desired directory name (based on app name). If another
+	// application's checkout already has that name, add part of the ID rather than
+	// pulling this application over it.
 	desiredDir := sanitizeDirName(selectedApp.Name)
+	if flagDirectory == "" && belongsToOtherApp(desiredDir, selectedApp) {
+		otherDir := desiredDir
+		desiredDir = disambiguateDirName(desiredDir, selectedApp.ID)
+		cmd.Printf("Note: '%s' holds a different application, using '%s' instead\n", otherDir, desiredDir)
+	}
 
 	// Determine the repository directory (use the repository name for git operations)
 	repoDir := filepath.Join(".", selectedApp.GithubRepositoryName)
 
-	// Determine which directory to use (prefer desiredDir, fall back to repoDir if it exists)
+	// Determine which directory to use (an explicit --directory wins; otherwise prefer
+	// desiredDir, falling back to repoDir if it exists)
 	var workingDir string
-	if _, err := os.Stat(desiredDir); err == nil {
+	if flagDirectory != "" {
+		workingDir = filepath.Clean(flagDirectory)
+		desiredDir = workingDir
+	} else if _, err := os.Stat(desiredDir); err == nil {
 		workingDir = desiredDir
-	} else if _, err := os.Stat(repoDir); err == nil {
+	} else if _, err := os.Stat(repoDir); err == nil && !belongsToOtherApp(repoDir, selectedApp) {
 		workingDir = repoDir
 	} else {
 		workingDir = desiredDir
 	}
 
+	// Never re-point another application's checkout at this one
+	if err := checkDirMatchesApp(workingDir, selectedApp); err != nil {
+		return err
+	}
+
 	// Ensure the directory is a properly configured git repository
 	gitErr := ensureGitRepository(cmd, workingDir, selectedApp.CloneURLSSH, selectedApp.CloneURLHTTPS)
 
@@ -174,34 +228,56 @@ func runClone(cmd *cobra.Command) error {
 		}
 	}
 
-	// Generate env file
-	cmd.Println("\nGenerating .env file...")
-	envFilePath, envVars, err := generateEnvFile(finalDir)
-	if err != nil {
-		return errors.WrapError("failed to generate .env file", err)
+	// Generate env file and MCP configs, which hold secrets
+	var envVars map[string]string
+	if flagCloneNoEnv {
+		cmd.Println("\nSkipping .env and MCP config generation (--no-env)")
+	} else {
+		cmd.Println("\nGenerating .env file...")
+		envFilePath, vars, err := generateEnvFile(finalDir)
+		if err != nil {
+			return errors.WrapError("failed to generate .env file", err)
+		}
+		envVars = vars
+		cmd.Printf("Successfully generated .env file at: %s\n", envFilePath)
+
+		// Generate .mcp.json for Claude Code
+		generateMcpConfig(cmd, finalDir, envVars)
 	}
-	cmd.Printf("Successfully generated .env file at: %s\n", envFilePath)
 
-	// Generate .mcp.json for Claude Code
-	if _, err := utils.GenerateMcpConfig(finalDir, envVars); err != nil {
-		cmd.Printf("Warning: Failed to generate .mcp.json: %v\n", err)
-	} else {
-		cmd.Println("✓ Generated .mcp.json for Claude Code")
+	// Generate RESOURCES.md; the clone is still usable without it
+	if !flagCloneNoResources {
+		if resourcesPath, _, err := utils.GenerateResourcesFile(finalDir); err != nil {
+			cmd.Printf("Warning: Failed to generate RESOURCES.md: %v\n", err)
+		} else {
+			cmd.Printf("Successfully generated RESOURCES.md at: %s\n", resourcesPath)
+		}
 	}
 
 	cmd.Println("\n✓ Application clone complete!")
 
+	if flagCloneRun {
+		return RunStartInDir(cmd, finalDir, envVars)
+	}
+
 	printSuccessMessage(cmd, finalDir)
 	return nil
 }
 
-// sanitizeDirName converts an application name to a valid directory name
+// dirNameReplacer spells out letters that don't decompose into an ASCII base letter
+var dirNameReplacer = strings.NewReplacer(
+	"ß", "ss", "æ", "ae", "œ", "oe", "ø", "o", "ł", "l", "đ", "d", "ð", "d", "þ", "th",
+)
+
+// sanitizeDirName converts an application name to a valid directory name.
+// Accents are stripped ("Café" becomes "cafe"); letters and digits from other
+// scripts are kept as-is rather than being dropped.
 func sanitizeDirName(name string) string {
-	// Convert to lowercase
-	dirName := strings.ToLower(name)
+	// Convert to lowercase and transliterate common accented letters
+	dirName := stripLatinAccents(dirNameReplacer.Replace(strings.ToLower(name)))
 
 	// Replace spaces and special characters with hyphens
-	reg := regexp.MustCompile(`[^a-z0-9]+`)
+	reg := regexp.MustCompile(`[^\p{L}\p{N}_]+`)
 	dirName = reg.ReplaceAllString(dirName, "-")
 
 	// Remove leading/trailing hyphens
@@ -215,6 +291,92 @@ func sanitizeDirName(name string) string {
 	return dirName
 }
 
+// stripLatinAccents removes combining marks from Latin letters. Marks on other
+// scripts are kept, since they can change the letter itself (e.g. Japanese dakuten).
+func stripLatinAccents(s string) string {
+	var b strings.Builder
+	latinBase := false
+	for _, r := range norm.NFD.String(s) {
+		if unicode.Is(unicode.Mn, r) {
+			if latinBase {
+				continue
+			}
+		} else {
+			latinBase = unicode.Is(unicode.Latin, r)
+		}
+		b.WriteRune(r)
+	}
+	return norm.NFC.String(b.String())
+}
+
+// belongsToOtherApp reports whether dir is a git repository whose origin points
+// at a different repository than app's. Directories without an origin don't count.
+func belongsToOtherApp(dir string, app *api.ApplicationItem) bool {
+	remoteURL, err := git.GetRemoteURLFromDir(dir)
+	if err != nil || remoteURL == "" {
+		return false
+	}
+
+	remote, err := git.ParseRemoteURL(remoteURL)
+	if err != nil {
+		return remoteURL != app.CloneURLSSH && remoteURL != app.CloneURLHTTPS
+	}
+	for _, cloneURL := range []string{app.CloneURLSSH, app.CloneURLHTTPS} {
+		if info, err := git.ParseRemoteURL(cloneURL); err == nil &&
+			strings.EqualFold(info.Owner, remote.Owner) && strings.EqualFold(info.Repo, remote.Repo) {
+			return false
+		}
+	}
+	return true
+}
+
+// checkDirMatchesApp returns an error when dir is a git checkout whose origin
+// resolves to a different application than app, so clone doesn't pull over it.
+// Missing directories and repositories without an origin pass.
+func checkDirMatchesApp(dir string, app *api.ApplicationItem) error {
+	if !git.IsGitRepositoryDir(dir) {
+		return nil
+	}
+	remoteURL, err := git.GetRemoteURLFromDir(dir)
+	if err != nil || remoteURL == "" {
+		return nil
+	}
+	if remoteURL == app.CloneURLSSH || remoteURL == app.CloneURLHTTPS {
+		return nil
+	}
+
+	mismatch := &errors.CLIError{
+		Title:      fmt.Sprintf("Directory '%s' belongs to a different repository", dir),
+		Suggestion: fmt.Sprintf("Its origin is %s. Pass --directory to clone '%s' somewhere else, or move that directory.", remoteURL, app.Name),
+	}
+
+	remote, err := git.ParseRemoteURL(remoteURL)
+	if err != nil {
+		return mismatch
+	}
+	resp, err := singletons.GetAPIClient().GetApplicationByRepo(remote.Owner, remote.Repo)
+	if err != nil {
+		if api.IsApplicationNotFound(err) || api.IsNoApplicationAccess(err) {
+			return mismatch
+		}
+		return errors.WrapError("failed to check which application '"+dir+"' belongs to", err)
+	}
+	if resp.ApplicationID != app.ID {
+		mismatch.Title = fmt.Sprintf("Directory '%s' belongs to a different application", dir)
+		return mismatch
+	}
+	return nil
+}
+
+// disambiguateDirName appends the start of the application ID to dirName
+func disambiguateDirName(dirName, appID string) string {
+	suffix := sanitizeDirName(appID)
+	if len(suffix) > 8 {
+		suffix = suffix[:8]
+	}
+	return dirName + "-" + suffix
+}
+
 // selectApplication prompts the user to select an application from the list
 func selectApplication(cmd *cobra.Command, apps []api.ApplicationItem) (*api.ApplicationItem, error) {
 	if len(apps) == 0 {
@@ -259,3 +421,32 @@ func selectApplication(cmd *cobra.Command, apps []api.ApplicationItem) (*api.App
 
 	return nil, errors.ErrorApplicationNotFound
 }
+
+// findApplication looks up an application by ID, then by case-insensitive name.
+// A name shared by several applications is rejected so the wrong one isn't cloned.
+func findApplication(apps []api.ApplicationItem, ref string) (*api.ApplicationItem, error) {
+	for i, app := range apps {
+		if app.ID == ref {
+			return &apps[i], nil
+		}
+	}
+
+	var match *api.ApplicationItem
+	for i, app := range apps {
+		if !strings.EqualFold(app.Name, ref) {
+			continue
+		}
+		if match != nil {
+			return nil, &errors.CLIError{
+				Title:      fmt.Sprintf("More than one application is named %q", ref),
+				Suggestion: "Pass the application ID instead. Run 'major app list' to see IDs.",
+			}
+		}
+		match = &apps[i]
+	}
+
+	if match == nil {
+		return nil, fmt.Errorf("application '%s' not found in your organization", ref)
+	}
+	return match, nil
+}
@@ -0,0 +1,101 @@
+package app
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/major-technology/cli/clients/api"
+)
+
+func TestFindApplication(t *testing.T) {
+	apps := []api.ApplicationItem{
+		{ID: "app-1", Name: "Dashboard"},
+		{ID: "app-2", Name: "Billing"},
+		{ID: "app-3", Name: "billing"},
+	}
+
+	tests := []struct {
+		ref     string
+		wantID  string
+		wantErr bool
+	}{
+		{ref: "app-2", wantID: "app-2"},
+		{ref: "dashboard", wantID: "app-1"},
+		{ref: "Billing", wantErr: true},
+		{ref: "missing", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := findApplication(apps, tt.ref)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("findApplication(%q) = %+v, want error", tt.ref, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("findApplication(%q) error: %v", tt.ref, err)
+			continue
+		}
+		if got.ID != tt.wantID {
+			t.Errorf("findApplication(%q).ID = %q, want %q", tt.ref, got.ID, tt.wantID)
+		}
+	}
+}
+
+func TestSanitizeDirName(t *testing.T) {
+	tests := map[string]string{
+		"My App":          "my-app",
+		"Café":            "cafe",
+		"Straße Planner":  "strasse-planner",
+		"Ørsted Økonomi":  "orsted-okonomi",
+		"  --Sales__Ops!": "sales__ops",
+		"販売ダッシュボード":       "販売ダッシュボード",
+		"!!!":             "app",
+	}
+
+	for name, want := range tests {
+		if got := sanitizeDirName(name); got != want {
+			t.Errorf("sanitizeDirName(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestDisambiguateDirName(t *testing.T) {
+	if got := disambiguateDirName("cafe", "3F2A9C1B-77aa-4e"); got != "cafe-3f2a9c1b" {
+		t.Fatalf("disambiguateDirName = %q, want %q", got, "cafe-3f2a9c1b")
+	}
+}
+
+func TestCheckDirMatchesApp(t *testing.T) {
+	useFakeAPIClient(t, &fakeAPIClient{repoAppIDs: map[string]string{
+		"acme/billing":   "app-1",
+		"acme/dashboard": "app-2",
+	}})
+	app := &api.ApplicationItem{ID: "app-1", Name: "Billing", CloneURLSSH: "git@github.com:acme/billing.git"}
+
+	newRepo := func(origin string) string {
+		dir := t.TempDir()
+		for _, args := range [][]string{{"init", "-q"}, {"remote", "add", "origin", origin}} {
+			cmd := exec.Command("git", args...)
+			cmd.Dir = dir
+			if out, err := cmd.CombinedOutput(); err != nil {
+				t.Fatalf("git %v: %v\n%s", args, err, out)
+			}
+		}
+		return dir
+	}
+
+	if err := checkDirMatchesApp(t.TempDir(), app); err != nil {
+		t.Errorf("plain directory: unexpected error %v", err)
+	}
+	if err := checkDirMatchesApp(newRepo("https://github.com/acme/billing.git"), app); err != nil {
+		t.Errorf("same application over HTTPS: unexpected error %v", err)
+	}
+	if err := checkDirMatchesApp(newRepo("git@github.com:acme/dashboard.git"), app); err == nil {
+		t.Error("different application: expected error")
+	}
+	if err := checkDirMatchesApp(newRepo("git@github.com:someone/unrelated.git"), app); err == nil {
+		t.Error("unknown repository: expected error")
+	}
+}
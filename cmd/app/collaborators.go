@@ -0,0 +1,72 @@
+package app
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/major-technology/cli/errors"
+	"github.com/major-technology/cli/middleware"
+	"github.com/major-technology/cli/singletons"
+	"github.com/major-technology/cli/utils"
+	"github.com/spf13/cobra"
+)
+
+// githubUsernamePattern matches GitHub usernames: alphanumerics and single hyphens, max 39 chars
+var githubUsernamePattern = regexp.MustCompile(`^[a-zA-Z0-9](?:[a-zA-Z0-9]|-[a-zA-Z0-9]){0,38}$`)
+
+// collaboratorsCmd represents the app collaborators command
+var collaboratorsCmd = &cobra.Command{
+	Use:   "collaborators",
+	Short: "Manage GitHub access to the application's repository",
+	Long:  `Commands for giving teammates access to the GitHub repository of the application in the current directory.`,
+	Args:  utils.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.Help()
+		return nil
+	},
+}
+
+// collaboratorsAddCmd represents the app collaborators add command
+var collaboratorsAddCmd = &cobra.Command{
+	Use:   "add <github-username>",
+	Short: "Invite a GitHub user to the application's repository",
+	Long: `Invites a GitHub user as a collaborator on the repository of the application in the
+current directory. They need to accept the invitation on GitHub before they can clone.`,
+	Args: cobra.ExactArgs(1),
+	PreRunE: middleware.Compose(
+		middleware.CheckGitInstalled,
+		middleware.CheckInGitRepository,
+	),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runCollaboratorsAdd(cmd, args[0])
+	},
+}
+
+func init() {
+	collaboratorsCmd.AddCommand(collaboratorsAddCmd)
+}
+
+func runCollaboratorsAdd(cmd *cobra.Command, username string) error {
+	username = strings.TrimPrefix(strings.TrimSpace(username), "@")
+	if !githubUsernamePattern.MatchString(username) {
+		return fmt.Errorf("invalid GitHub username %q", username)
+	}
+
+	applicationID, err := getApplicationID()
+	if err != nil {
+		return err
+	}
+
+	message, err := utils.AddGithubCollaboratorWithRetry(cmd, singletons.GetAPIClient(), applicationID, username)
+	if err != nil {
+		return errors.WrapError("failed to add GitHub collaborator", err)
+	}
+
+	cmd.Printf("✓ Invited %s to the repository\n", username)
+	if message != "" {
+		cmd.Println(message)
+	}
+	cmd.Println("They need to accept the invitation on GitHub before they can clone the application.")
+	return nil
+}
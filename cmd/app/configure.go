@@ -3,21 +3,32 @@ package app
 import (
 	"fmt"
 
+	"github.com/major-technology/cli/middleware"
 	"github.com/major-technology/cli/singletons"
 	"github.com/major-technology/cli/utils"
 	"github.com/spf13/cobra"
 )
 
+var flagConfigurePrint bool
+
 // configureCmd represents the configure command
 var configureCmd = &cobra.Command{
 	Use:   "configure",
 	Short: "Open the app configurations in your browser",
 	Long:  `Open the app configurations in your default browser for the current application.`,
+	PreRunE: middleware.Compose(
+		middleware.CheckGitInstalled,
+		middleware.CheckInGitRepository,
+	),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return runConfigure(cmd)
 	},
 }
 
+func init() {
+	configureCmd.Flags().BoolVar(&flagConfigurePrint, "print", false, "Print the URL instead of opening a browser (for headless machines)")
+}
+
 func runConfigure(cmd *cobra.Command) error {
 	// Get application ID
 	applicationID, err := getApplicationID()
@@ -31,6 +42,11 @@ func runConfigure(cmd *cobra.Command) error {
 	// Construct the app settings URL
 	configureURL := fmt.Sprintf("%s/home?dialog=app-settings&appId=%s", cfg.FrontendURI, applicationID)
 
+	if flagConfigurePrint {
+		fmt.Fprintln(cmd.OutOrStdout(), configureURL)
+		return nil
+	}
+
 	// Open the URL in the browser
 	if err := utils.OpenBrowser(configureURL); err != nil {
 		// If browser fails to open, still show the URL
@@ -2,11 +2,13 @@ package app
 
 import (
 	"fmt"
+	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/charmbracelet/huh"
 	"github.com/charmbracelet/lipgloss"
-	"github.com/major-technology/cli/clients/api"
+	"github.com/major-technology/cli/clients/git"
 	mjrToken "github.com/major-technology/cli/clients/token"
 	"github.com/major-technology/cli/errors"
 	"github.com/major-technology/cli/middleware"
@@ -19,6 +21,10 @@ import (
 var (
 	flagAppName        string
 	flagAppDescription string
+	flagCreateHere     bool
+	flagCreateForce    bool
+	flagCreateResume   bool
+	flagCreateFrom     string
 )
 
 // createCmd represents the create command
@@ -32,9 +38,23 @@ You can also provide these values via flags for non-interactive usage:
 
   major app create --name "my-app" --description "My application"
 
+Use --here to set the app up in the current directory instead of a new one. The
+directory must be empty unless --force is passed, in which case existing files are
+kept and the repository's files are added alongside them.
+
+Use --from to start from your own template repository on GitHub instead of the
+default one. Its files replace the default template's in the new repository:
+
+  major app create --name "my-app" --description "My application" --from https://github.com/acme/starter
+
+If creation is interrupted after the application exists, run 'major app create --resume'
+to finish the remaining steps instead of creating a duplicate.
+
 GitHub username is auto-detected from your SSH configuration.`,
 	PreRunE: middleware.Compose(
+		middleware.RequireOnline,
 		middleware.CheckLogin,
+		middleware.CheckGitInstalled,
 	),
 	RunE: func(cobraCmd *cobra.Command, args []string) error {
 		return runCreate(cobraCmd)
@@ -45,9 +65,51 @@ func init() {
 	createCmd.Flags().StringVar(&flagAppName, "name", "", "Application name (skips interactive prompt)")
 	createCmd.Flags().StringVar(&flagAppDescription, "description", "", "Application description (skips interactive prompt)")
 	createCmd.Flags().StringVar(&flagGithubUser, "github-user", "", "GitHub username for repository access (for non-interactive mode)")
+	createCmd.Flags().StringSliceVar(&flagEditors, "editors", []string{"claude"}, "AI editors to write MCP configs for (claude, cursor, vscode)")
+	createCmd.Flags().BoolVar(&flagNoGitignore, "no-gitignore", false, "Don't add generated MCP configs to .gitignore")
+	createCmd.Flags().StringVar(&flagGitProtocol, "git-protocol", utils.GitProtocolAuto, "Git transport: ssh, https, or auto to use SSH when it works with GitHub")
+	createCmd.Flags().BoolVar(&flagCreateHere, "here", false, "Create the app in the current directory instead of a new subdirectory")
+	createCmd.Flags().BoolVar(&flagCreateForce, "force", false, "With --here, allow a current directory that isn't empty")
+	createCmd.Flags().BoolVar(&flagCreateResume, "resume", false, "Finish an interrupted 'major app create' from where it stopped")
+	createCmd.Flags().StringVar(&flagCreateFrom, "from", "", "GitHub repository URL to use as the template instead of the default one")
 }
 
 func runCreate(cobraCmd *cobra.Command) error {
+	if flagCreateResume {
+		return runCreateResume(cobraCmd)
+	}
+
+	if _, err := utils.ParseMcpEditors(flagEditors); err != nil {
+		return errors.WrapError("invalid --editors value", err)
+	}
+	if err := utils.ValidateGitProtocol(flagGitProtocol); err != nil {
+		return err
+	}
+	if flagCreateForce && !flagCreateHere {
+		return &errors.CLIError{
+			Title:      "--force can only be used with --here",
+			Suggestion: "Run 'major app create --here --force' to create the app in a non-empty current directory.",
+		}
+	}
+
+	// Check the template URL before creating anything, so a typo leaves no app behind
+	if flagCreateFrom != "" {
+		if _, err := git.ParseRemoteURL(flagCreateFrom); err != nil {
+			return &errors.CLIError{
+				Title:      fmt.Sprintf("--from %q is not a GitHub repository URL", flagCreateFrom),
+				Suggestion: "Use a URL like https://github.com/owner/repo or git@github.com:owner/repo.git.",
+				Err:        err,
+			}
+		}
+	}
+
+	// Check the current directory before creating anything, so a refusal leaves no app behind
+	if flagCreateHere {
+		if err := checkHereDir(".", flagCreateForce); err != nil {
+			return err
+		}
+	}
+
 	// Get default org from keychain
 	orgID, orgName, err := mjrToken.GetDefaultOrg()
 	if err != nil {
@@ -137,6 +199,16 @@ func runCreate(cobraCmd *cobra.Command) error {
 
 	cobraCmd.Printf("\nCreating application '%s'...\n", appName)
 
+	// Record the target as an absolute path so --resume works from any directory
+	targetDir := appName
+	if flagCreateHere {
+		targetDir = "."
+	}
+	targetDir, err = filepath.Abs(targetDir)
+	if err != nil {
+		return errors.WrapError("failed to resolve the app directory", err)
+	}
+
 	createResp, err := apiClient.CreateApplication(appName, appDescription, orgID, themeIDPtr)
 	if err != nil {
 		return err
@@ -145,95 +217,304 @@ func runCreate(cobraCmd *cobra.Command) error {
 	cobraCmd.Printf("✓ Application created with ID: %s\n", createResp.ApplicationID)
 	cobraCmd.Printf("✓ Repository: %s\n", createResp.RepositoryName)
 
-	// Ensure repository access before cloning
-	// Use non-interactive mode if all required flags were provided
-	isNonInteractive := flagAppName != "" && flagAppDescription != ""
-	opts := utils.EnsureRepositoryAccessOptions{
-		NonInteractive: isNonInteractive,
-		GithubUsername: flagGithubUser,
-	}
-	err = utils.EnsureRepositoryAccessWithOptions(cobraCmd, createResp.ApplicationID, createResp.CloneURLSSH, createResp.CloneURLHTTPS, opts)
-
-	// Check if invitation is pending (user needs to accept)
-	if invErr, ok := err.(*utils.InvitationPendingError); ok {
-		cobraCmd.Println("")
-		cobraCmd.Println("╭─────────────────────────────────────────────────────────────╮")
-		cobraCmd.Println("│                                                             │")
-		cobraCmd.Println("│  Action Required: Accept GitHub Invitation                  │")
-		cobraCmd.Println("│                                                             │")
-		if invErr.URL != "" {
-			cobraCmd.Printf("│  %-59s │\n", invErr.URL)
+	state := &createState{
+		ApplicationID:  createResp.ApplicationID,
+		OrganizationID: orgID,
+		AppName:        appName,
+		CloneURLSSH:    createResp.CloneURLSSH,
+		CloneURLHTTPS:  createResp.CloneURLHTTPS,
+		TargetDir:      targetDir,
+		Here:           flagCreateHere,
+		TemplateURL:    flagCreateFrom,
+		// Use non-interactive mode if all required flags were provided
+		NonInteractive: flagAppName != "" && flagAppDescription != "",
+	}
+	storeCreateState(state)
+
+	return finishCreate(cobraCmd, state)
+}
+
+// runCreateResume finishes an interrupted 'major app create' from its saved state
+func runCreateResume(cobraCmd *cobra.Command) error {
+	var state *createState
+	if path, err := createStatePath(); err == nil {
+		state = loadCreateState(path)
+	}
+	if state == nil {
+		return &errors.CLIError{
+			Title:      "No interrupted app creation to resume",
+			Suggestion: "Run 'major app create' to create a new application.",
+		}
+	}
+
+	cobraCmd.Printf("Resuming creation of '%s' (ID: %s)\n", state.AppName, state.ApplicationID)
+	return finishCreate(cobraCmd, state)
+}
+
+// finishCreate runs the setup steps after the application exists on the server. Steps
+// that state records as done are skipped, and progress is saved after each one.
+func finishCreate(cobraCmd *cobra.Command, state *createState) (err error) {
+	// The application exists, so point any failure at resuming rather than creating again
+	defer func() {
+		if err != nil {
+			err = errors.ErrorAppSetupIncomplete(state.ApplicationID, "major app create --resume", err)
+		}
+	}()
+
+	apiClient := singletons.GetAPIClient()
+
+	if !state.Cloned {
+		// Ensure repository access before cloning
+		opts := utils.EnsureRepositoryAccessOptions{
+			NonInteractive: state.NonInteractive,
+			GithubUsername: flagGithubUser,
+		}
+		err = utils.EnsureRepositoryAccessWithOptions(cobraCmd, state.ApplicationID, state.CloneURLSSH, state.CloneURLHTTPS, opts)
+
+		// Check if invitation is pending (user needs to accept)
+		if invErr, ok := err.(*utils.InvitationPendingError); ok {
+			cobraCmd.Println("")
+			cobraCmd.Println("╭─────────────────────────────────────────────────────────────╮")
+			cobraCmd.Println("│                                                             │")
+			cobraCmd.Println("│  Action Required: Accept GitHub Invitation                  │")
 			cobraCmd.Println("│                                                             │")
+			if invErr.URL != "" {
+				cobraCmd.Printf("│  %-59s │\n", invErr.URL)
+				cobraCmd.Println("│                                                             │")
+			}
+			cobraCmd.Println("│  After accepting, finish setting up the app with:           │")
+			cobraCmd.Println("│  major app create --resume                                  │")
+			cobraCmd.Println("│                                                             │")
+			cobraCmd.Println("╰─────────────────────────────────────────────────────────────╯")
+			return nil // Exit cleanly, template pushed but clone pending user's invitation acceptance
+		}
+
+		if err != nil {
+			return errors.WrapError("failed to ensure repository access", err)
 		}
-		cobraCmd.Println("│  After accepting, clone the app with:                        │")
-		cobraCmd.Printf("│  major app clone --app-id \"%s\"  │\n", createResp.ApplicationID)
-		cobraCmd.Println("│                                                             │")
-		cobraCmd.Println("╰─────────────────────────────────────────────────────────────╯")
-		return nil // Exit cleanly, template pushed but clone pending user's invitation acceptance
 	}
 
-	if err != nil {
-		return errors.WrapError("failed to ensure repository access", err)
+	// Replace the server's template with the --from one before anything clones it
+	if state.TemplateURL != "" && !state.TemplatePushed {
+		cobraCmd.Printf("\nUsing template %s...\n", state.TemplateURL)
+		if err := pushTemplate(state); err != nil {
+			return err
+		}
+		state.TemplatePushed = true
+		storeCreateState(state)
+		cobraCmd.Println("✓ Template pushed to the application's repository")
 	}
 
 	// Select resources for the application (skip in non-interactive mode)
-	var selectedResources []api.ResourceItem
-	if !isNonInteractive {
+	if !state.ResourcesSelected && !state.NonInteractive {
 		cobraCmd.Println("\nSelecting resources for your application...")
-		selectedResources, err = utils.SelectApplicationResources(cobraCmd, apiClient, orgID, createResp.ApplicationID)
+		// The app is new, so there is nothing to remove and no change to confirm
+		state.Resources, err = utils.SelectApplicationResourcesWithOptions(cobraCmd, apiClient, state.OrganizationID, state.ApplicationID, utils.SelectApplicationResourcesOptions{
+			SkipConfirm: true,
+		})
 		if err != nil {
 			return errors.ErrorFailedToSelectResources
 		}
+		state.ResourcesSelected = true
+		storeCreateState(state)
 	}
 
-	// Clone the repository (which now has template content)
-	targetDir := filepath.Join(".", appName)
-	cobraCmd.Printf("\nCloning repository to %s...\n", targetDir)
-	_, gitErr := cloneRepository(createResp.CloneURLSSH, createResp.CloneURLHTTPS, targetDir)
-	if gitErr != nil {
-		return errors.WrapError("failed to clone repository", gitErr)
+	targetDir := displayDir(state.TargetDir)
+	location := targetDir
+	if targetDir == "." {
+		location = "the current directory"
 	}
 
-	cobraCmd.Printf("✓ Application '%s' successfully created in ./%s\n", appName, appName)
+	// Clone the repository (which now has template content)
+	if !state.Cloned {
+		cobraCmd.Printf("\nCloning repository to %s...\n", targetDir)
+		if err := cloneCreatedApp(state); err != nil {
+			return errors.WrapError("failed to clone repository", err)
+		}
+		state.Cloned = true
+		storeCreateState(state)
+
+		cobraCmd.Printf("✓ Application '%s' successfully created in %s\n", state.AppName, location)
+	}
 
 	// If resources were selected, add them using major-client
-	if len(selectedResources) > 0 {
-		if err := utils.AddResourcesToProject(cobraCmd, targetDir, selectedResources, createResp.ApplicationID); err != nil {
-			return errors.ErrorFailedToSelectResources
+	if len(state.Resources) > 0 && !state.ResourcesAdded {
+		// The repository already exists at this point, so keep going and let the user link resources later
+		if err := utils.AddResourcesToProject(cobraCmd, state.TargetDir, state.Resources, state.ApplicationID); err != nil {
+			cobraCmd.Printf("Warning: Failed to add resources to the project: %v\n", err)
+			cobraCmd.Printf("Your application was still created. Run 'major resource manage' in %s to link resources later.\n", location)
 		}
+		state.ResourcesAdded = true
+		storeCreateState(state)
 	}
 
 	// Generate .env file
 	cobraCmd.Println("\nGenerating .env file...")
-	envFilePath, envVars, err := generateEnvFile(targetDir)
+	envFilePath, envVars, err := generateEnvFile(state.TargetDir)
 	if err != nil {
 		cobraCmd.Printf("Warning: Failed to generate .env file: %v\n", err)
 	} else {
 		cobraCmd.Printf("✓ Generated .env file at: %s\n", envFilePath)
 
 		// Generate .mcp.json for Claude Code
-		if _, err := utils.GenerateMcpConfig(targetDir, envVars); err != nil {
-			cobraCmd.Printf("Warning: Failed to generate .mcp.json: %v\n", err)
-		} else {
-			cobraCmd.Println("✓ Generated .mcp.json for Claude Code")
-		}
+		generateMcpConfig(cobraCmd, state.TargetDir, envVars)
 	}
 
 	// Generate theme files
 	cobraCmd.Println("Generating theme files...")
-	if err := generateThemeFiles(targetDir); err != nil {
+	if err := generateThemeFiles(state.TargetDir); err != nil {
 		cobraCmd.Printf("Warning: Failed to generate theme files: %v\n", err)
 	} else {
 		cobraCmd.Println("✓ Theme files generated")
 	}
 
-	printSuccessMessage(cobraCmd, appName)
+	clearCreateState()
+	printSuccessMessage(cobraCmd, targetDir)
 
 	return nil
 }
 
-// printSuccessMessage displays a nicely formatted success message with next steps
-func printSuccessMessage(cobraCmd *cobra.Command, appName string) {
+// pushTemplate replaces the new repository's contents with the latest commit of
+// state.TemplateURL, the same way 'major demo create' pushes its template
+func pushTemplate(state *createState) error {
+	repoURL, _, err := utils.SelectCloneURL(flagGitProtocol, state.CloneURLSSH, state.CloneURLHTTPS)
+	if err != nil {
+		return err
+	}
+
+	tempDir, err := os.MkdirTemp("", "major-template-*")
+	if err != nil {
+		return errors.WrapError("failed to create temp directory", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	if err := git.CloneShallow(state.TemplateURL, tempDir); err != nil {
+		return errors.WrapError("failed to clone template repository", err)
+	}
+	// A shallow clone can't be pushed to another repository, so start from a fresh root commit
+	if err := git.ResetHistory(tempDir, "Initial commit from template"); err != nil {
+		return errors.WrapError("failed to prepare template history", err)
+	}
+	if err := git.RemoveRemote(tempDir, "origin"); err != nil {
+		return errors.WrapError("failed to remove template remote", err)
+	}
+	if err := git.AddRemote(tempDir, "origin", repoURL); err != nil {
+		return errors.WrapError("failed to add application remote", err)
+	}
+	if err := git.Push(tempDir); err != nil {
+		return errors.WrapError("failed to push template to the application's repository", err)
+	}
+	return nil
+}
+
+// cloneCreatedApp clones the new application's repository into its target directory,
+// keeping existing files when --here was used on a non-empty directory
+func cloneCreatedApp(state *createState) error {
+	if state.Here {
+		empty, err := isDirEmpty(state.TargetDir)
+		if err != nil {
+			return err
+		}
+		if !empty {
+			return cloneIntoDir(state.CloneURLSSH, state.CloneURLHTTPS, state.TargetDir)
+		}
+	}
+
+	_, err := cloneRepository(state.CloneURLSSH, state.CloneURLHTTPS, state.TargetDir)
+	return err
+}
+
+// displayDir returns dir relative to the working directory when it is inside it,
+// for messages and the cd hint
+func displayDir(dir string) string {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return dir
+	}
+	rel, err := filepath.Rel(cwd, dir)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return dir
+	}
+	return rel
+}
+
+// isDirEmpty reports whether dir has no entries
+func isDirEmpty(dir string) (bool, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return false, err
+	}
+	return len(entries) == 0, nil
+}
+
+// checkHereDir reports whether the app can be created in dir with --here. A non-empty
+// dir needs force, and a dir that is already a git repository is always refused,
+// since the clone would conflict on .git after the app had been created.
+func checkHereDir(dir string, force bool) error {
+	if _, err := os.Lstat(filepath.Join(dir, ".git")); err == nil {
+		return &errors.CLIError{
+			Title:      "The current directory is already a git repository",
+			Suggestion: "Run in a directory without .git, or drop --here to create the app in a new directory.",
+		}
+	}
+
+	empty, err := isDirEmpty(dir)
+	if err != nil {
+		return errors.WrapError("failed to read the current directory", err)
+	}
+	if !empty && !force {
+		return &errors.CLIError{
+			Title:      "The current directory is not empty",
+			Suggestion: "Run in an empty directory, pass --force to keep the existing files, or drop --here to create the app in a new directory.",
+		}
+	}
+	return nil
+}
+
+// cloneIntoDir clones the repository into dir when it already has files, which git
+// clone refuses. It clones into a temporary subdirectory and moves the checkout up,
+// leaving existing files alone and refusing if any of them would be overwritten.
+func cloneIntoDir(sshURL, httpsURL, dir string) error {
+	tmpDir, err := os.MkdirTemp(dir, ".major-tmp-*")
+	if err != nil {
+		return errors.WrapError("failed to create temporary clone directory", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	if _, err := cloneRepository(sshURL, httpsURL, tmpDir); err != nil {
+		return err
+	}
+
+	entries, err := os.ReadDir(tmpDir)
+	if err != nil {
+		return errors.WrapError("failed to read cloned repository", err)
+	}
+
+	var conflicts []string
+	for _, entry := range entries {
+		if _, err := os.Lstat(filepath.Join(dir, entry.Name())); err == nil {
+			conflicts = append(conflicts, entry.Name())
+		}
+	}
+	if len(conflicts) > 0 {
+		return &errors.CLIError{
+			Title:      fmt.Sprintf("The repository would overwrite existing files: %s", strings.Join(conflicts, ", ")),
+			Suggestion: "Move those files aside.",
+		}
+	}
+
+	for _, entry := range entries {
+		if err := os.Rename(filepath.Join(tmpDir, entry.Name()), filepath.Join(dir, entry.Name())); err != nil {
+			return errors.WrapError("failed to move cloned files into place", err)
+		}
+	}
+
+	return nil
+}
+
+// printSuccessMessage displays a nicely formatted success message with next steps.
+// The cd step is left out when appDir is the current directory.
+func printSuccessMessage(cobraCmd *cobra.Command, appDir string) {
 	// Define styles
 	successStyle := lipgloss.NewStyle().
 		Bold(true).
@@ -266,11 +547,14 @@ func printSuccessMessage(cobraCmd *cobra.Command, appName string) {
 	// Build the message
 	successMsg := successStyle.Render("🎉 Congrats on setting up your app!")
 
-	// CD instruction
-	cdInstruction := cdStyle.Render(fmt.Sprintf("First, navigate to your app directory:\n  cd %s", appName))
-
 	nextStepsTitle := titleStyle.Render("What's next?")
 
+	// CD instruction, unless the app is already in the current directory
+	header := nextStepsTitle
+	if filepath.Clean(appDir) != "." {
+		header += "\n\n" + cdStyle.Render(fmt.Sprintf("First, navigate to your app directory:\n  cd %s", appDir))
+	}
+
 	// Commands with improved descriptions
 	startCommand := commandStyle.Render("major app start")
 	startDesc := descriptionStyle.Render("  Start your app locally for development")
@@ -281,9 +565,8 @@ func printSuccessMessage(cobraCmd *cobra.Command, appName string) {
 	resourceCommand := commandStyle.Render("major resource manage")
 	resourceDesc := descriptionStyle.Render("  Manage the resources your app is connected to")
 
-	content := fmt.Sprintf("%s\n\n%s\n\n%s\n%s\n\n%s\n%s\n\n%s\n%s",
-		nextStepsTitle,
-		cdInstruction,
+	content := fmt.Sprintf("%s\n\n%s\n%s\n\n%s\n%s\n\n%s\n%s",
+		header,
 		startCommand,
 		startDesc,
 		deployCommand,
@@ -0,0 +1,79 @@
+package app
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/major-technology/cli/clients/api"
+	"github.com/major-technology/cli/utils"
+)
+
+// createState records how far 'major app create' got, so --resume can finish an
+// interrupted creation. It is stored in ~/.major/cache and removed once creation completes.
+type createState struct {
+	ApplicationID  string             `json:"applicationId"`
+	OrganizationID string             `json:"organizationId"`
+	AppName        string             `json:"appName"`
+	CloneURLSSH    string             `json:"cloneUrlSsh"`
+	CloneURLHTTPS  string             `json:"cloneUrlHttps"`
+	TargetDir      string             `json:"targetDir"`
+	Here           bool               `json:"here"`
+	NonInteractive bool               `json:"nonInteractive"`
+	Resources      []api.ResourceItem `json:"resources,omitempty"`
+	// TemplateURL is the --from repository pushed over the server's template, if any
+	TemplateURL string `json:"templateUrl,omitempty"`
+
+	// Steps completed so far
+	TemplatePushed    bool `json:"templatePushed,omitempty"`
+	ResourcesSelected bool `json:"resourcesSelected"`
+	Cloned            bool `json:"cloned"`
+	ResourcesAdded    bool `json:"resourcesAdded"`
+}
+
+func createStatePath() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(homeDir, ".major", "cache", "app-create.json"), nil
+}
+
+// storeCreateState saves state to the default location, ignoring failures
+func storeCreateState(state *createState) {
+	if path, err := createStatePath(); err == nil {
+		saveCreateState(path, state)
+	}
+}
+
+// clearCreateState removes the saved state once creation has completed
+func clearCreateState() {
+	if path, err := createStatePath(); err == nil {
+		os.Remove(path)
+	}
+}
+
+// loadCreateState reads the saved state, returning nil if there is none or it can't be read
+func loadCreateState(path string) *createState {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+
+	var state createState
+	if json.Unmarshal(data, &state) != nil || state.ApplicationID == "" {
+		return nil
+	}
+	return &state
+}
+
+// saveCreateState writes state to path. Failures are ignored; they only cost
+// the ability to resume.
+func saveCreateState(path string, state *createState) {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return
+	}
+	os.MkdirAll(filepath.Dir(path), 0755)
+	utils.WriteFileAtomic(path, data, 0600)
+}
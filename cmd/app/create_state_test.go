@@ -0,0 +1,116 @@
+package app
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/major-technology/cli/clients/api"
+	"github.com/major-technology/cli/utils"
+)
+
+func TestCreateStateRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache", "app-create.json")
+
+	if state := loadCreateState(path); state != nil {
+		t.Fatalf("loadCreateState with no file = %+v, want nil", state)
+	}
+
+	saveCreateState(path, &createState{
+		ApplicationID:     "app-1",
+		AppName:           "dashboard",
+		TargetDir:         "/work/dashboard",
+		Resources:         []api.ResourceItem{{ID: "res-1", Name: "db"}},
+		ResourcesSelected: true,
+		Cloned:            true,
+	})
+
+	state := loadCreateState(path)
+	if state == nil {
+		t.Fatal("loadCreateState = nil, want the saved state")
+	}
+	if state.ApplicationID != "app-1" || state.TargetDir != "/work/dashboard" {
+		t.Errorf("state = %+v, want the saved application and directory", state)
+	}
+	if !state.ResourcesSelected || !state.Cloned || state.ResourcesAdded {
+		t.Errorf("steps = selected %v, cloned %v, added %v; want true, true, false", state.ResourcesSelected, state.Cloned, state.ResourcesAdded)
+	}
+	if len(state.Resources) != 1 || state.Resources[0].ID != "res-1" {
+		t.Errorf("resources = %+v, want res-1", state.Resources)
+	}
+}
+
+func TestLoadCreateStateIgnoresInvalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app-create.json")
+	if err := os.WriteFile(path, []byte("{not json"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if state := loadCreateState(path); state != nil {
+		t.Errorf("loadCreateState = %+v, want nil", state)
+	}
+}
+
+func TestDisplayDir(t *testing.T) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	outside := filepath.Join(filepath.Dir(cwd), "elsewhere")
+
+	tests := []struct {
+		dir  string
+		want string
+	}{
+		{dir: cwd, want: "."},
+		{dir: filepath.Join(cwd, "my-app"), want: "my-app"},
+		{dir: outside, want: outside},
+	}
+
+	for _, tt := range tests {
+		if got := displayDir(tt.dir); got != tt.want {
+			t.Errorf("displayDir(%q) = %q, want %q", tt.dir, got, tt.want)
+		}
+	}
+}
+
+func TestPushTemplate(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	git := func(dir string, args ...string) string {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+		return string(out)
+	}
+
+	template := t.TempDir()
+	git(template, "init", "-q", "-b", "main")
+	if err := os.WriteFile(filepath.Join(template, "README.md"), []byte("template\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	git(template, "add", "README.md")
+	git(template, "-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-q", "-m", "template")
+
+	remote := t.TempDir()
+	git(remote, "init", "-q", "--bare", "-b", "main")
+
+	oldProtocol := flagGitProtocol
+	flagGitProtocol = utils.GitProtocolHTTPS
+	t.Cleanup(func() { flagGitProtocol = oldProtocol })
+
+	state := &createState{TemplateURL: "file://" + template, CloneURLHTTPS: remote}
+	if err := pushTemplate(state); err != nil {
+		t.Fatalf("pushTemplate: %v", err)
+	}
+
+	if got := git(remote, "show", "main:README.md"); got != "template\n" {
+		t.Errorf("README.md on main = %q, want the template's", got)
+	}
+}
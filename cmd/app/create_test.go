@@ -0,0 +1,117 @@
+package app
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/major-technology/cli/utils"
+)
+
+func TestCheckHereDir(t *testing.T) {
+	empty := t.TempDir()
+
+	nonEmpty := t.TempDir()
+	if err := os.WriteFile(filepath.Join(nonEmpty, "notes.txt"), []byte("keep\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	repo := t.TempDir()
+	if err := os.Mkdir(filepath.Join(repo, ".git"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		dir     string
+		force   bool
+		wantErr string
+	}{
+		{name: "empty", dir: empty},
+		{name: "non-empty", dir: nonEmpty, wantErr: "not empty"},
+		{name: "non-empty with force", dir: nonEmpty, force: true},
+		{name: "git repository", dir: repo, wantErr: "already a git repository"},
+		{name: "git repository with force", dir: repo, force: true, wantErr: "already a git repository"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkHereDir(tt.dir, tt.force)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("checkHereDir = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("checkHereDir = %v, want an error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCloneIntoDir(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	git := func(dir string, args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+
+	remote := t.TempDir()
+	git(remote, "init", "-q", "-b", "main")
+	if err := os.WriteFile(filepath.Join(remote, "README.md"), []byte("app\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	git(remote, "add", "README.md")
+	git(remote, "-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-q", "-m", "init")
+
+	oldProtocol := flagGitProtocol
+	flagGitProtocol = utils.GitProtocolHTTPS
+	t.Cleanup(func() { flagGitProtocol = oldProtocol })
+
+	t.Run("keeps existing files", func(t *testing.T) {
+		dir := t.TempDir()
+		if err := os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("keep\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := cloneIntoDir("", remote, dir); err != nil {
+			t.Fatalf("cloneIntoDir: %v", err)
+		}
+		for _, name := range []string{".git", "README.md", "notes.txt"} {
+			if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+				t.Errorf("%s missing after clone: %v", name, err)
+			}
+		}
+	})
+
+	t.Run("refuses to overwrite", func(t *testing.T) {
+		dir := t.TempDir()
+		readme := filepath.Join(dir, "README.md")
+		if err := os.WriteFile(readme, []byte("mine\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		err := cloneIntoDir("", remote, dir)
+		if err == nil || !strings.Contains(err.Error(), "README.md") {
+			t.Fatalf("cloneIntoDir = %v, want a conflict on README.md", err)
+		}
+		if data, _ := os.ReadFile(readme); string(data) != "mine\n" {
+			t.Errorf("README.md = %q, want it left alone", data)
+		}
+		if _, err := os.Stat(filepath.Join(dir, ".git")); !os.IsNotExist(err) {
+			t.Errorf(".git was moved in despite the conflict")
+		}
+		entries, _ := os.ReadDir(dir)
+		if len(entries) != 1 {
+			t.Errorf("dir has %d entries, want only README.md", len(entries))
+		}
+	})
+}
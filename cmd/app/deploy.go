@@ -1,8 +1,10 @@
 package app
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
 	"regexp"
 	"strings"
 	"time"
@@ -12,9 +14,13 @@ import (
 	"github.com/charmbracelet/huh"
 	"github.com/charmbracelet/lipgloss"
 	xt "github.com/charmbracelet/x/term"
+	"github.com/major-technology/cli/clients/api"
 	"github.com/major-technology/cli/clients/git"
+	"github.com/major-technology/cli/clients/process"
 	"github.com/major-technology/cli/errors"
+	"github.com/major-technology/cli/middleware"
 	"github.com/major-technology/cli/singletons"
+	"github.com/major-technology/cli/utils"
 	"github.com/spf13/cobra"
 )
 
@@ -23,12 +29,24 @@ var (
 	flagDeployMessage string
 	flagDeploySlug    string
 	flagDeployNoWait  bool
+	flagDeployWait    bool
+	flagDeployOutput  string
+	flagDeployYes     bool
+	flagDeployEnv     string
+	flagPreDeploy     []string
+	flagNoCommit      bool
 )
 
 func init() {
 	deployCmd.Flags().StringVarP(&flagDeployMessage, "message", "m", "", "Commit message for uncommitted changes (skips interactive prompt)")
 	deployCmd.Flags().StringVar(&flagDeploySlug, "slug", "", "URL slug for first deploy (skips interactive prompt)")
 	deployCmd.Flags().BoolVar(&flagDeployNoWait, "no-wait", false, "Don't wait for deployment to complete (returns immediately after triggering)")
+	deployCmd.Flags().BoolVar(&flagDeployWait, "wait", true, "Wait for the deployment to complete; use --wait=false to trigger and exit")
+	deployCmd.Flags().BoolVarP(&flagDeployYes, "yes", "y", false, "Commit and push uncommitted changes without confirmation")
+	deployCmd.Flags().BoolVar(&flagNoCommit, "no-commit", false, "Deploy the current commit only; fail instead of committing if there are uncommitted changes")
+	deployCmd.Flags().StringSliceVar(&flagPreDeploy, "pre-deploy", nil, "package.json scripts to run with pnpm before committing, e.g. --pre-deploy lint,build; the deploy stops if one fails")
+	deployCmd.Flags().StringVar(&flagDeployEnv, "env", "", "Deploy to this environment (by name) without switching to it")
+	deployCmd.Flags().StringVar(&flagDeployOutput, "output", "", "Deploy progress format: text or json (defaults to json when stdout is not a terminal)")
 }
 
 // deployCmd represents the deploy command
@@ -36,15 +54,19 @@ var deployCmd = &cobra.Command{
 	Use:   "deploy",
 	Short: "Deploy a new version of the application",
 	Long:  `Creates a new version by committing and pushing changes, then deploying to the platform.`,
+	PreRunE: middleware.Compose(
+		middleware.RequireOnline,
+		middleware.CheckGitInstalled,
+		middleware.CheckInGitRepository,
+	),
 	RunE: func(cobraCmd *cobra.Command, args []string) error {
 		return runDeploy(cobraCmd)
 	},
 }
 
 func runDeploy(cobraCmd *cobra.Command) error {
-	// Check if we're in a git repository
-	if !git.IsGitRepository() {
-		return errors.ErrorNotInGitRepository
+	if flagDeployOutput != "" && flagDeployOutput != "text" && flagDeployOutput != "json" {
+		return fmt.Errorf("invalid --output value %q, must be one of: text, json", flagDeployOutput)
 	}
 
 	// Get application ID, organization ID, and URL slug
@@ -53,14 +75,43 @@ func runDeploy(cobraCmd *cobra.Command) error {
 		return errors.WrapError("failed to get application ID", err)
 	}
 
+	// Resolve the target environment before anything is committed or pushed
+	var environmentID string
+	if flagDeployEnv != "" {
+		environmentID, err = resolveEnvironmentID(applicationID, flagDeployEnv)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Run local checks before anything is committed or pushed
+	if err := runPreDeployScripts(cobraCmd, flagPreDeploy); err != nil {
+		return err
+	}
+
+	// Keep generated secret files out of the commit
+	if err := protectSecretFiles(); err != nil {
+		return err
+	}
+
 	// Check for uncommitted changes
-	hasChanges, err := git.HasUncommittedChanges()
+	changes, err := git.Status("")
 	if err != nil {
-		return errors.WrapError("failed to check for uncommitted changes: %w", err)
+		return errors.WrapError("failed to check for uncommitted changes", err)
+	}
+
+	if len(changes) > 0 && flagNoCommit {
+		return &errors.CLIError{
+			Title:      fmt.Sprintf("Uncommitted changes (%d files); commit or stash first", len(changes)),
+			Suggestion: "--no-commit deploys only what's already committed. Run 'git status' to see the changes, or drop --no-commit to commit them as part of the deploy.",
+		}
 	}
 
-	if hasChanges {
+	if len(changes) > 0 {
 		cobraCmd.Println("📝 Uncommitted changes detected")
+		if err := confirmChanges(cobraCmd, changes); err != nil {
+			return err
+		}
 
 		var commitMessage string
 
@@ -131,30 +182,51 @@ func runDeploy(cobraCmd *cobra.Command) error {
 
 	// Call API to create new version
 	apiClient := singletons.GetAPIClient()
-	resp, err := apiClient.CreateApplicationVersion(applicationID, deploySlug)
+	resp, err := apiClient.CreateApplicationVersion(applicationID, deploySlug, environmentID)
 	if err != nil {
 		return err
 	}
 
 	cobraCmd.Printf("\n✓ Version created: %s\n", resp.VersionID)
+	if flagDeployEnv != "" {
+		cobraCmd.Printf("  Environment: %s\n", flagDeployEnv)
+	}
 
-	// If --no-wait, return immediately
-	if flagDeployNoWait {
+	// If --wait=false or --no-wait, return immediately
+	if !flagDeployWait || flagDeployNoWait {
 		cobraCmd.Printf("Deployment started. Use 'major app deploy-status --version-id %s' to check status.\n", resp.VersionID)
 		return nil
 	}
 
-	// Poll deployment status -- use simple polling if not a TTY, Bubble Tea otherwise
+	// Poll deployment status -- JSON events when requested or not a TTY, Bubble Tea on an
+	// interactive terminal, and simple line-based polling otherwise
+	isTTY := xt.IsTerminal(os.Stdout.Fd())
+	if flagDeployOutput == "json" || (flagDeployOutput == "" && !isTTY) {
+		finalStatus, err := pollDeploymentStatusJSON(cobraCmd, applicationID, organizationID, resp.VersionID)
+		if err != nil {
+			return errors.WrapError("failed to track deployment status", err)
+		}
+		if finalStatus != "DEPLOYED" {
+			return fmt.Errorf("deployment failed with status: %s", finalStatus)
+		}
+		return nil
+	}
+
+	timer := &deployPhaseTimer{}
 	var finalStatus, deploymentError, appURL string
-	if xt.IsTerminal(os.Stdout.Fd()) {
-		finalStatus, deploymentError, appURL, err = pollDeploymentStatus(applicationID, organizationID, resp.VersionID)
+	if utils.IsInteractiveTerminal() {
+		finalStatus, deploymentError, appURL, err = pollDeploymentStatus(applicationID, organizationID, resp.VersionID, timer)
 	} else {
-		finalStatus, deploymentError, appURL, err = pollDeploymentStatusSimple(cobraCmd, applicationID, organizationID, resp.VersionID)
+		finalStatus, deploymentError, appURL, err = pollDeploymentStatusSimple(cobraCmd, applicationID, organizationID, resp.VersionID, timer)
 	}
 	if err != nil {
 		return errors.WrapError("failed to track deployment status", err)
 	}
 
+	if summary := timer.summary(time.Now()); summary != "" {
+		cobraCmd.Printf("\n⏱  %s\n", summary)
+	}
+
 	// Print final status
 	if finalStatus == "DEPLOYED" {
 		cobraCmd.Printf("\n🎉 Deployment successful!\n")
@@ -187,9 +259,11 @@ type deploymentStatusModel struct {
 	appURL          string
 	err             error
 	done            bool
-	dots            int  // Track number of dots (0-4)
-	dotsIncreasing  bool // Track if dots are increasing or decreasing
-	tickCounter     int  // Counter to slow down dot animation
+	timer           *deployPhaseTimer
+	backoff         time.Duration // Delay between polls while rate limited
+	dots            int           // Track number of dots (0-4)
+	dotsIncreasing  bool          // Track if dots are increasing or decreasing
+	tickCounter     int           // Counter to slow down dot animation
 }
 
 type statusMsg struct {
@@ -217,7 +291,15 @@ func (m deploymentStatusModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 	case statusMsg:
+		// Back off instead of failing when the status endpoint is rate limiting us
+		if api.IsRateLimited(msg.err) {
+			m.backoff = nextRateLimitBackoff(m.backoff)
+			return m, tickCmd(m.backoff)
+		}
+		m.backoff = 0
+
 		m.status = msg.status
+		m.timer.observe(m.status, time.Now())
 		m.deploymentError = msg.deploymentError
 		m.appURL = msg.appURL
 		m.err = msg.err
@@ -228,8 +310,8 @@ func (m deploymentStatusModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, tea.Quit
 		}
 
-		// Wait 2 seconds before polling again
-		return m, tickCmd()
+		// Wait before polling again
+		return m, tickCmd(time.Second)
 
 	case tickMsg:
 		// Time to poll for status update
@@ -310,8 +392,8 @@ func pollStatus(applicationID, organizationID, versionID string) tea.Cmd {
 	}
 }
 
-func tickCmd() tea.Cmd {
-	return tea.Tick(1*time.Second, func(t time.Time) tea.Msg {
+func tickCmd(d time.Duration) tea.Cmd {
+	return tea.Tick(d, func(t time.Time) tea.Msg {
 		return tickMsg(t)
 	})
 }
@@ -352,7 +434,7 @@ func getStatusDisplay(status string) (string, string) {
 	}
 }
 
-func pollDeploymentStatus(applicationID, organizationID, versionID string) (string, string, string, error) {
+func pollDeploymentStatus(applicationID, organizationID, versionID string, timer *deployPhaseTimer) (string, string, string, error) {
 	s := spinner.New()
 	s.Spinner = spinner.Dot
 	s.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("205"))
@@ -369,6 +451,7 @@ func pollDeploymentStatus(applicationID, organizationID, versionID string) (stri
 		dots:            1,
 		dotsIncreasing:  true,
 		tickCounter:     0,
+		timer:           timer,
 	}
 
 	p := tea.NewProgram(m)
@@ -426,16 +509,172 @@ func validateSlug(s string) error {
 	return nil
 }
 
+// confirmChanges lists the files the deploy is about to commit, warns about any
+// that usually hold secrets, and asks for confirmation unless --yes was passed.
+// Without an interactive terminal the prompt is skipped, except when secret files
+// are included, which then requires --yes.
+func confirmChanges(cobraCmd *cobra.Command, changes []git.FileStatus) error {
+	cobraCmd.Println("\nThe following files will be committed and pushed:")
+	var secretFiles []string
+	for _, change := range changes {
+		cobraCmd.Printf("  %s %s\n", change.State, change.Path)
+		if isSecretFile(change.Path) {
+			secretFiles = append(secretFiles, change.Path)
+		}
+	}
+	cobraCmd.Println()
+
+	if len(secretFiles) > 0 {
+		cobraCmd.Printf("⚠️  Warning: %s usually contain secrets and should not be committed.\n\n", strings.Join(secretFiles, ", "))
+	}
+
+	if flagDeployYes {
+		return nil
+	}
+
+	if !utils.IsInteractiveTerminal() {
+		if len(secretFiles) > 0 {
+			return &errors.CLIError{
+				Title:      "Refusing to commit files that may contain secrets",
+				Suggestion: "Remove them from the commit (add them to .gitignore), or pass --yes to commit anyway.",
+			}
+		}
+		return nil
+	}
+
+	var confirm bool
+	form := huh.NewForm(
+		huh.NewGroup(
+			huh.NewConfirm().
+				Title(fmt.Sprintf("Commit and push %d changed file(s)?", len(changes))).
+				Value(&confirm),
+		),
+	)
+	if err := form.Run(); err != nil {
+		return errors.WrapError("failed to confirm changes", err)
+	}
+	if !confirm {
+		return errors.ErrorOperationCancelled
+	}
+
+	return nil
+}
+
+// runPreDeployScripts runs each package.json script with pnpm from the repository
+// root. Script output goes to stderr so --output json keeps stdout clean.
+func runPreDeployScripts(cobraCmd *cobra.Command, scripts []string) error {
+	if len(scripts) == 0 {
+		return nil
+	}
+
+	repoRoot, err := git.GetRepoRoot()
+	if err != nil {
+		return errors.WrapError("failed to find repository root", err)
+	}
+
+	for _, script := range scripts {
+		script = strings.TrimSpace(script)
+		if script == "" {
+			continue
+		}
+
+		cobraCmd.Printf("Running pnpm run %s...\n", script)
+		scriptCmd := process.CommandTimeout(process.LongTimeout, "pnpm", "run", script)
+		scriptCmd.Dir = repoRoot
+		scriptCmd.Stdout = os.Stderr
+		scriptCmd.Stderr = os.Stderr
+
+		if err := scriptCmd.Run(); err != nil {
+			return &errors.CLIError{
+				Title:      fmt.Sprintf("Pre-deploy script '%s' failed", script),
+				Suggestion: "Fix the errors above and deploy again. Nothing was committed or pushed.",
+				Err:        err,
+			}
+		}
+		cobraCmd.Printf("✓ pnpm run %s passed\n", script)
+	}
+
+	return nil
+}
+
+// protectSecretFiles makes sure .env and .mcp.json are ignored before the deploy
+// stages everything, and refuses to continue if a generated secret file is
+// already tracked, since it would be pushed on every deploy.
+func protectSecretFiles() error {
+	gitRoot, err := git.GetRepoRoot()
+	if err != nil {
+		return errors.ErrorNotInGitRepository
+	}
+
+	paths := []string{".env"}
+	for _, editor := range utils.McpEditors {
+		paths = append(paths, filepath.ToSlash(editor.ConfigPath))
+	}
+
+	tracked, err := git.GetTrackedFiles(paths...)
+	if err != nil {
+		return errors.WrapError("failed to check tracked files", err)
+	}
+	if len(tracked) > 0 {
+		return &errors.CLIError{
+			Title:      fmt.Sprintf("Secret files are tracked by git: %s", strings.Join(tracked, ", ")),
+			Suggestion: fmt.Sprintf("Stop tracking them with 'git rm --cached %s', then deploy again.", strings.Join(tracked, " ")),
+		}
+	}
+
+	utils.EnsureGitignoreEntry(gitRoot, ".env")
+	utils.EnsureGitignoreEntry(gitRoot, ".mcp.json")
+
+	return nil
+}
+
+// isSecretFile reports whether path is a file the CLI generates with credentials,
+// such as .env or an MCP config.
+func isSecretFile(path string) bool {
+	path = filepath.ToSlash(path)
+	base := filepath.Base(path)
+	if base == ".env" || (strings.HasPrefix(base, ".env.") && base != ".env.example") {
+		return true
+	}
+	for _, editor := range utils.McpEditors {
+		if path == filepath.ToSlash(editor.ConfigPath) {
+			return true
+		}
+	}
+	return false
+}
+
+// nextRateLimitBackoff doubles the delay between status polls while rate
+// limited, starting at 5 seconds and capping at one minute.
+func nextRateLimitBackoff(current time.Duration) time.Duration {
+	if current <= 0 {
+		return 5 * time.Second
+	}
+	if current*2 > time.Minute {
+		return time.Minute
+	}
+	return current * 2
+}
+
 // pollDeploymentStatusSimple polls deployment status using simple text output (for non-TTY environments).
-func pollDeploymentStatusSimple(cobraCmd *cobra.Command, applicationID, organizationID, versionID string) (string, string, string, error) {
+func pollDeploymentStatusSimple(cobraCmd *cobra.Command, applicationID, organizationID, versionID string, timer *deployPhaseTimer) (string, string, string, error) {
 	apiClient := singletons.GetAPIClient()
 	lastStatus := ""
+	var backoff time.Duration
 
 	for {
 		resp, err := apiClient.GetVersionStatus(applicationID, organizationID, versionID)
+		if api.IsRateLimited(err) {
+			backoff = nextRateLimitBackoff(backoff)
+			cobraCmd.Printf("Rate limited, checking again in %s\n", backoff)
+			time.Sleep(backoff)
+			continue
+		}
 		if err != nil {
 			return "", "", "", err
 		}
+		backoff = 0
+		timer.observe(resp.Status, time.Now())
 
 		if resp.Status != lastStatus {
 			statusText, _ := getStatusDisplay(resp.Status)
@@ -451,6 +690,127 @@ func pollDeploymentStatusSimple(cobraCmd *cobra.Command, applicationID, organiza
 	}
 }
 
+// deployPhase is a deployment status and when it was first seen
+type deployPhase struct {
+	status string
+	start  time.Time
+}
+
+// deployPhaseTimer records when each deployment status is first seen, to report
+// how long bundling, building and deploying took
+type deployPhaseTimer struct {
+	phases []deployPhase
+}
+
+// deployPhaseNames maps the in-progress statuses to how the summary describes them
+var deployPhaseNames = map[string]struct{ done, failed string }{
+	"BUNDLING":  {done: "bundled", failed: "bundle failed"},
+	"BUILDING":  {done: "built", failed: "build failed"},
+	"DEPLOYING": {done: "deployed", failed: "deploy failed"},
+}
+
+// observe records status if it differs from the last one seen
+func (t *deployPhaseTimer) observe(status string, now time.Time) {
+	if n := len(t.phases); n > 0 && t.phases[n-1].status == status {
+		return
+	}
+	t.phases = append(t.phases, deployPhase{status: status, start: now})
+}
+
+// summary describes how long each phase took, e.g. "Bundled in 12s, built in 45s,
+// deployed in 8s". A phase that ended in a failure is reported as failed. Phases
+// never seen while polling are left out.
+func (t *deployPhaseTimer) summary(end time.Time) string {
+	var parts []string
+	for i, phase := range t.phases {
+		names, ok := deployPhaseNames[phase.status]
+		if !ok {
+			continue
+		}
+
+		finish := end
+		failed := false
+		if i+1 < len(t.phases) {
+			next := t.phases[i+1].status
+			finish = t.phases[i+1].start
+			failed = isTerminalStatus(next) && next != "DEPLOYED"
+		}
+
+		duration := finish.Sub(phase.start).Round(time.Second)
+		if failed {
+			parts = append(parts, fmt.Sprintf("%s after %s", names.failed, duration))
+		} else {
+			parts = append(parts, fmt.Sprintf("%s in %s", names.done, duration))
+		}
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+
+	summary := strings.Join(parts, ", ")
+	return strings.ToUpper(summary[:1]) + summary[1:]
+}
+
+// deployEvent is one line of JSON deploy progress. The last event has Final set
+// along with the outcome.
+type deployEvent struct {
+	Version   string `json:"version"`
+	Status    string `json:"status"`
+	Timestamp string `json:"timestamp"`
+	Final     bool   `json:"final,omitempty"`
+	Success   *bool  `json:"success,omitempty"`
+	AppURL    string `json:"appUrl,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// pollDeploymentStatusJSON polls deployment status and writes a JSON line to stdout for
+// each status transition, followed by a final event. Returns the terminal status.
+func pollDeploymentStatusJSON(cobraCmd *cobra.Command, applicationID, organizationID, versionID string) (string, error) {
+	encoder := json.NewEncoder(cobraCmd.OutOrStdout())
+	lastStatus := ""
+	var backoff time.Duration
+
+	for {
+		msg := pollStatus(applicationID, organizationID, versionID)().(statusMsg)
+		if api.IsRateLimited(msg.err) {
+			backoff = nextRateLimitBackoff(backoff)
+			time.Sleep(backoff)
+			continue
+		}
+		if msg.err != nil {
+			return "", msg.err
+		}
+		backoff = 0
+
+		event := deployEvent{
+			Version:   versionID,
+			Status:    msg.status,
+			Timestamp: time.Now().UTC().Format(time.RFC3339),
+		}
+
+		if isTerminalStatus(msg.status) {
+			success := msg.status == "DEPLOYED"
+			event.Final = true
+			event.Success = &success
+			event.AppURL = msg.appURL
+			event.Error = msg.deploymentError
+			if err := encoder.Encode(event); err != nil {
+				return "", err
+			}
+			return msg.status, nil
+		}
+
+		if msg.status != lastStatus {
+			if err := encoder.Encode(event); err != nil {
+				return "", err
+			}
+			lastStatus = msg.status
+		}
+
+		time.Sleep(2 * time.Second)
+	}
+}
+
 // promptForDeployURL prompts the user for a deploy URL slug on first deploy.
 func promptForDeployURL(cobraCmd *cobra.Command) (string, error) {
 	cfg := singletons.GetConfig()
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/major-technology/cli/errors"
+	"github.com/major-technology/cli/middleware"
 	"github.com/major-technology/cli/singletons"
 	"github.com/spf13/cobra"
 )
@@ -15,6 +16,10 @@ var deployStatusCmd = &cobra.Command{
 	Use:   "deploy-status",
 	Short: "Check the status of a deployment",
 	Long:  `Returns the current deployment status for a given version ID.`,
+	PreRunE: middleware.Compose(
+		middleware.CheckGitInstalled,
+		middleware.CheckInGitRepository,
+	),
 	RunE: func(cobraCmd *cobra.Command, args []string) error {
 		return runDeployStatus(cobraCmd)
 	},
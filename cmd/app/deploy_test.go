@@ -0,0 +1,60 @@
+package app
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDeployPhaseTimerSummary(t *testing.T) {
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	at := func(seconds int) time.Time {
+		return start.Add(time.Duration(seconds) * time.Second)
+	}
+
+	tests := []struct {
+		name     string
+		statuses []string
+		times    []int
+		end      int
+		want     string
+	}{
+		{
+			name:     "successful deploy",
+			statuses: []string{"BUNDLING", "BUILDING", "DEPLOYING", "DEPLOYED"},
+			times:    []int{0, 12, 57, 65},
+			end:      65,
+			want:     "Bundled in 12s, built in 45s, deployed in 8s",
+		},
+		{
+			name:     "repeated polls of the same status",
+			statuses: []string{"BUNDLING", "BUNDLING", "BUILDING", "BUILDING", "DEPLOYED"},
+			times:    []int{0, 5, 10, 20, 30},
+			end:      30,
+			want:     "Bundled in 10s, built in 20s",
+		},
+		{
+			name:     "failed build",
+			statuses: []string{"BUNDLING", "BUILDING", "BUILD_FAILED"},
+			times:    []int{0, 3, 40},
+			end:      40,
+			want:     "Bundled in 3s, build failed after 37s",
+		},
+		{
+			name:     "no phases seen",
+			statuses: []string{"DEPLOYED"},
+			times:    []int{0},
+			end:      0,
+			want:     "",
+		},
+	}
+
+	for _, tt := range tests {
+		timer := &deployPhaseTimer{}
+		for i, status := range tt.statuses {
+			timer.observe(status, at(tt.times[i]))
+		}
+		if got := timer.summary(at(tt.end)); got != tt.want {
+			t.Errorf("%s: summary = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
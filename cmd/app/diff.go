@@ -0,0 +1,112 @@
+package app
+
+import (
+	"github.com/major-technology/cli/clients/git"
+	"github.com/major-technology/cli/errors"
+	"github.com/major-technology/cli/middleware"
+	"github.com/major-technology/cli/singletons"
+	"github.com/spf13/cobra"
+)
+
+// diffCmd represents the diff command
+var diffCmd = &cobra.Command{
+	Use:   "diff",
+	Short: "Show what changed since the deployed version",
+	Long: `Compare your local checkout with the currently deployed version of the application.
+
+Lists the commits and files between the deployed commit and HEAD, plus any uncommitted
+changes that 'major app deploy' would commit first.`,
+	PreRunE: middleware.Compose(
+		middleware.CheckGitInstalled,
+		middleware.CheckInGitRepository,
+	),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runDiff(cmd)
+	},
+}
+
+func runDiff(cmd *cobra.Command) error {
+	applicationID, err := getApplicationID()
+	if err != nil {
+		return err
+	}
+
+	appInfo, err := singletons.GetAPIClient().GetApplicationInfo(applicationID)
+	if err != nil {
+		return errors.WrapError("failed to get application info", err)
+	}
+
+	if appInfo.DeployedCommitHash == nil || *appInfo.DeployedCommitHash == "" {
+		cmd.Println("This application hasn't been deployed yet. Everything in your checkout would be deployed.")
+	} else if err := printDeployedDiff(cmd, *appInfo.DeployedCommitHash); err != nil {
+		return err
+	}
+
+	changes, err := git.Status("")
+	if err != nil {
+		return errors.WrapError("failed to check for uncommitted changes", err)
+	}
+	if len(changes) > 0 {
+		cmd.Printf("\nUncommitted changes (%d), committed on deploy:\n", len(changes))
+		for _, change := range changes {
+			cmd.Printf("  %s %s\n", change.State, change.Path)
+		}
+	}
+
+	return nil
+}
+
+// printDeployedDiff prints the commits and changed files between deployed and HEAD
+func printDeployedDiff(cmd *cobra.Command, deployed string) error {
+	cmd.Printf("Deployed commit: %s\n", shortCommit(deployed))
+
+	if !git.HasCommit(deployed) {
+		// The deployed commit may only exist on the remote
+		if err := git.FetchOrigin(); err != nil {
+			cmd.Printf("Warning: %v\n", err)
+		}
+	}
+	if !git.HasCommit(deployed) {
+		cmd.Printf("\nWarning: Commit %s isn't in your local history. The branch may have been force-pushed or rewritten since the last deploy, so the next deploy replaces the deployed version entirely.\n", shortCommit(deployed))
+		return nil
+	}
+
+	commits, err := git.LogOneline(deployed, "HEAD")
+	if err != nil {
+		return errors.WrapError("failed to list commits since the deployed version", err)
+	}
+	stat, err := git.DiffStat(deployed, "HEAD")
+	if err != nil {
+		return errors.WrapError("failed to diff against the deployed version", err)
+	}
+
+	if len(commits) == 0 && stat == "" {
+		cmd.Println("✓ HEAD matches the deployed version")
+		return nil
+	}
+
+	if len(commits) > 0 {
+		cmd.Printf("\nCommits since deploy (%d):\n", len(commits))
+		for _, commit := range commits {
+			cmd.Printf("  %s\n", commit)
+		}
+	} else {
+		// HEAD is behind or on a different branch than the deployed commit
+		cmd.Println("\nNo new commits since deploy, but HEAD differs from the deployed version.")
+	}
+
+	if stat != "" {
+		cmd.Println("\nFiles changed:")
+		cmd.Println(stat)
+	}
+
+	return nil
+}
+
+// shortCommit abbreviates a commit hash for display
+func shortCommit(hash string) string {
+	if len(hash) < 12 {
+		return hash
+	}
+	return hash[:12]
+}
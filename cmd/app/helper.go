@@ -4,6 +4,7 @@ import (
 	stderrors "errors"
 	"fmt"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"strings"
 	"time"
@@ -75,15 +76,13 @@ func getApplicationAndOrgIDFromDir(dir string) (string, string, string, error) {
 	return appResp.ApplicationID, appResp.OrganizationID, urlSlug, nil
 }
 
-// getPreferredCloneURL returns the preferred clone URL based on SSH availability
+// flagGitProtocol forces the git transport (ssh, https, or auto-detect)
+var flagGitProtocol = utils.GitProtocolAuto
+
+// getPreferredCloneURL returns the clone URL for --git-protocol, auto-detecting
+// SSH availability by default
 func getPreferredCloneURL(sshURL, httpsURL string) (url string, method string, err error) {
-	if utils.CanUseSSH() && sshURL != "" {
-		return sshURL, "SSH", nil
-	}
-	if httpsURL != "" {
-		return httpsURL, "HTTPS", nil
-	}
-	return "", "", fmt.Errorf("no valid clone method available")
+	return utils.SelectCloneURL(flagGitProtocol, sshURL, httpsURL)
 }
 
 // ensureGitRepository ensures a directory is a properly configured git repository.
@@ -105,7 +104,8 @@ func ensureGitRepository(cmd *cobra.Command, targetDir, sshURL, httpsURL string)
 	}
 
 	// Directory exists - check if it's a git repo
-	if !git.IsGitRepositoryDir(targetDir) {
+	isExistingRepo := git.IsGitRepositoryDir(targetDir)
+	if !isExistingRepo {
 		// Not a git repo - initialize and set origin
 		cmd.Printf("Directory '%s' exists but is not a git repository. Initializing...\n", targetDir)
 		if err := git.InitRepository(targetDir); err != nil {
@@ -119,27 +119,83 @@ func ensureGitRepository(cmd *cobra.Command, targetDir, sshURL, httpsURL string)
 		return errors.WrapError("failed to set git origin", err)
 	}
 
+	stashed := false
+	if isExistingRepo {
+		stashed, err = stashLocalChanges(cmd, targetDir)
+		if err != nil {
+			return err
+		}
+	}
+
 	// Pull latest changes
 	cmd.Printf("Pulling latest changes...\n")
-	return git.Pull(targetDir)
+	pullErr := git.Pull(targetDir)
+
+	// Restore local changes even if the pull failed
+	if stashed {
+		cmd.Println("Restoring your local changes...")
+		if err := git.StashPop(targetDir); err != nil {
+			if pullErr != nil {
+				return &errors.CLIError{
+					Title:      "Failed to pull, and your local changes couldn't be restored",
+					Suggestion: fmt.Sprintf("Your changes are still saved in the stash. Check 'git status' in '%s', then run 'git stash pop'.", targetDir),
+					Err:        stderrors.Join(pullErr, err),
+				}
+			}
+			return &errors.CLIError{
+				Title:      "Your local changes conflict with the pulled updates",
+				Suggestion: fmt.Sprintf("Resolve the conflicts in '%s' (see 'git status'), then run 'git stash drop'. Your changes are still saved in the stash.", targetDir),
+				Err:        err,
+			}
+		}
+		cmd.Println("✓ Local changes restored")
+	}
+
+	return pullErr
 }
 
-// cloneRepository clones a repository using SSH or HTTPS based on availability
-// Returns the clone method used ("SSH" or "HTTPS") and any error
-func cloneRepository(sshURL, httpsURL, targetDir string) (string, error) {
-	// Determine which clone URL to use
-	useSSH := false
-	if utils.CanUseSSH() && sshURL != "" {
-		useSSH = true
-	} else if httpsURL == "" {
-		return "", fmt.Errorf("no valid clone method available")
+// stashLocalChanges stashes uncommitted changes in targetDir before a pull, when
+// --stash is passed or the user agrees at the prompt. Returns whether a stash was made.
+func stashLocalChanges(cmd *cobra.Command, targetDir string) (bool, error) {
+	changes, err := git.Status(targetDir)
+	if err != nil {
+		return false, errors.WrapError("failed to check for local changes", err)
+	}
+	if len(changes) == 0 {
+		return false, nil
+	}
+
+	stash := flagStash
+	if !stash && utils.IsInteractiveTerminal() {
+		form := huh.NewForm(
+			huh.NewGroup(
+				huh.NewConfirm().
+					Title(fmt.Sprintf("'%s' has %d uncommitted change(s). Stash them while pulling?", targetDir, len(changes))).
+					Description("Your changes are reapplied after the pull.").
+					Value(&stash),
+			),
+		)
+		if err := form.Run(); err != nil {
+			return false, errors.WrapError("failed to confirm stash", err)
+		}
+	}
+	if !stash {
+		return false, nil
+	}
+
+	if err := git.Stash(targetDir, "major: local changes before pull"); err != nil {
+		return false, errors.WrapError("failed to stash local changes", err)
 	}
+	cmd.Printf("✓ Stashed %d local change(s)\n", len(changes))
+	return true, nil
+}
 
-	cloneURL := httpsURL
-	cloneMethod := "HTTPS"
-	if useSSH {
-		cloneURL = sshURL
-		cloneMethod = "SSH"
+// cloneRepository clones a repository using the transport chosen by --git-protocol
+// Returns the clone method used ("SSH" or "HTTPS") and any error
+func cloneRepository(sshURL, httpsURL, targetDir string) (string, error) {
+	cloneURL, cloneMethod, err := getPreferredCloneURL(sshURL, httpsURL)
+	if err != nil {
+		return "", err
 	}
 
 	// Clone the repository
@@ -169,11 +225,25 @@ func isGitAuthError(err error) bool {
 		"fatal: unable to access",
 	}
 
-	// Check all errors in the chain
+	// Check every message in the chain, plus git's own output: an exec.ExitError
+	// only says "exit status 128", the reason is on stderr
+	var messages []string
 	for e := err; e != nil; e = stderrors.Unwrap(e) {
-		errMsg := strings.ToLower(e.Error())
+		messages = append(messages, e.Error())
+	}
+	var cmdErr *git.CommandError
+	if stderrors.As(err, &cmdErr) {
+		messages = append(messages, cmdErr.Output)
+	}
+	var exitErr *exec.ExitError
+	if stderrors.As(err, &exitErr) {
+		messages = append(messages, string(exitErr.Stderr))
+	}
+
+	for _, msg := range messages {
+		msg = strings.ToLower(msg)
 		for _, pattern := range authErrorPatterns {
-			if strings.Contains(errMsg, pattern) {
+			if strings.Contains(msg, pattern) {
 				return true
 			}
 		}
@@ -209,8 +279,13 @@ func ensureGitRepositoryWithRetries(cmd *cobra.Command, workingDir, sshURL, http
 	return errors.ErrorGitRepositoryAccessFailed
 }
 
+// flagEnv names the environment to generate .env for. When empty, the
+// application's currently-selected environment is used.
+var flagEnv string
+
 // generateEnvFile generates a .env file for the application in the specified directory.
 // If targetDir is empty, it uses the current git repository root.
+// If --env is set, the variables come from that environment without switching to it.
 // Returns the path to the generated file and the env vars map.
 func generateEnvFile(targetDir string) (string, map[string]string, error) {
 	applicationID, orgID, _, err := getApplicationAndOrgIDFromDir(targetDir)
@@ -218,11 +293,12 @@ func generateEnvFile(targetDir string) (string, map[string]string, error) {
 		return "", nil, errors.WrapError("failed to get application ID", err)
 	}
 
-	apiClient := singletons.GetAPIClient()
-
-	envVars, err := apiClient.GetApplicationEnv(orgID, applicationID)
-	if err != nil {
-		return "", nil, errors.WrapError("failed to get environment variables", err)
+	var environmentID string
+	if flagEnv != "" {
+		environmentID, err = resolveEnvironmentID(applicationID, flagEnv)
+		if err != nil {
+			return "", nil, err
+		}
 	}
 
 	gitRoot := targetDir
@@ -233,22 +309,62 @@ func generateEnvFile(targetDir string) (string, map[string]string, error) {
 		}
 	}
 
-	// Create .env file path
-	envFilePath := filepath.Join(gitRoot, ".env")
+	return utils.GenerateEnvFile(gitRoot, orgID, applicationID, utils.EnvFileOptions{EnvironmentID: environmentID})
+}
+
+// resolveEnvironmentID looks up an environment by name (case-insensitive) and
+// returns its ID. The error lists the valid names when there's no match.
+func resolveEnvironmentID(applicationID, name string) (string, error) {
+	listResp, err := singletons.GetAPIClient().ListApplicationEnvironments(applicationID)
+	if err != nil {
+		return "", errors.WrapError("failed to list environments", err)
+	}
+
+	names := make([]string, 0, len(listResp.Environments))
+	for _, env := range listResp.Environments {
+		if strings.EqualFold(env.Name, name) {
+			return env.ID, nil
+		}
+		names = append(names, env.Name)
+	}
 
-	// Build the .env file content
-	var envContent strings.Builder
-	for key, value := range envVars {
-		envContent.WriteString(fmt.Sprintf("%s=%s\n", key, value))
+	return "", &errors.CLIError{
+		Title:      fmt.Sprintf("Environment %q not found", name),
+		Suggestion: fmt.Sprintf("Valid environments: %s", strings.Join(names, ", ")),
 	}
+}
 
-	// Write to .env file
-	err = os.WriteFile(envFilePath, []byte(envContent.String()), 0644)
+// flagEditors selects the AI editors that get an MCP config. Commands that don't
+// register --editors keep the default of Claude Code only.
+var flagEditors = []string{"claude"}
+
+// flagNoGitignore stops MCP config generation from adding entries to .gitignore.
+var flagNoGitignore bool
+
+// generateMcpConfig writes the MCP config for each editor in --editors into
+// targetDir and reports the result. Applications whose env doesn't include the
+// MCP variables are skipped with a note rather than a warning.
+func generateMcpConfig(cmd *cobra.Command, targetDir string, envVars map[string]string) {
+	editors, err := utils.ParseMcpEditors(flagEditors)
 	if err != nil {
-		return "", nil, errors.WrapError("failed to write .env file", err)
+		cmd.Printf("Warning: Failed to generate MCP config: %v\n", err)
+		return
 	}
 
-	return envFilePath, envVars, nil
+	for _, editor := range editors {
+		if _, err := utils.GenerateEditorMcpConfig(targetDir, envVars, editor, utils.McpConfigOptions{
+			SkipGitignore: flagNoGitignore,
+		}); err != nil {
+			if stderrors.Is(err, utils.ErrMcpEnvVarsMissing) {
+				cmd.Println("Skipping MCP config: this application's env doesn't include MCP variables")
+				return
+			}
+			cmd.Printf("Warning: Failed to generate %s: %v\n", editor.ConfigPath, err)
+			continue
+		}
+
+		cmd.Printf("✓ Generated %s for %s\n", editor.ConfigPath, editor.DisplayName)
+	}
 }
 
 // generateThemeFiles generates theme files (theme.css, theme.ts, logo.tsx) for the application.
@@ -416,7 +532,7 @@ func dimText(s string) string {
 
 // buildThemeSelectField builds a huh.Select field for theme selection.
 // Returns the field and a pointer to the selected value. Returns nil if no themes available.
-func buildThemeSelectField(apiClient *api.Client, orgID string, selectedID *string) (huh.Field, error) {
+func buildThemeSelectField(apiClient api.APIClient, orgID string, selectedID *string) (huh.Field, error) {
 	resp, err := apiClient.ListThemes(orgID)
 	if err != nil {
 		return nil, err
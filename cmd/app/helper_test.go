@@ -0,0 +1,103 @@
+package app
+
+import (
+	stderrors "errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/major-technology/cli/clients/api"
+	"github.com/major-technology/cli/clients/git"
+	clierrors "github.com/major-technology/cli/errors"
+	"github.com/major-technology/cli/singletons"
+)
+
+// fakeAPIClient embeds api.APIClient so tests only implement the calls they expect;
+// any other call panics on the nil interface.
+type fakeAPIClient struct {
+	api.APIClient
+	environments []api.EnvironmentItem
+	repoAppIDs   map[string]string // "owner/repo" -> application ID
+}
+
+func (f *fakeAPIClient) ListApplicationEnvironments(applicationID string) (*api.ListEnvironmentsResponse, error) {
+	return &api.ListEnvironmentsResponse{Environments: f.environments}, nil
+}
+
+func (f *fakeAPIClient) GetApplicationByRepo(owner, repo string) (*api.GetApplicationByRepoResponse, error) {
+	id, ok := f.repoAppIDs[owner+"/"+repo]
+	if !ok {
+		return nil, clierrors.ErrorApplicationNotFoundAPI
+	}
+	return &api.GetApplicationByRepoResponse{ApplicationID: id}, nil
+}
+
+func useFakeAPIClient(t *testing.T, fake api.APIClient) {
+	t.Helper()
+	prev := singletons.GetAPIClient()
+	singletons.SetAPIClient(fake)
+	t.Cleanup(func() { singletons.SetAPIClient(prev) })
+}
+
+func TestResolveEnvironmentID(t *testing.T) {
+	useFakeAPIClient(t, &fakeAPIClient{environments: []api.EnvironmentItem{
+		{ID: "env-1", Name: "Development"},
+		{ID: "env-2", Name: "Production"},
+	}})
+
+	id, err := resolveEnvironmentID("app-1", "production")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if id != "env-2" {
+		t.Fatalf("id = %q, want %q", id, "env-2")
+	}
+
+	_, err = resolveEnvironmentID("app-1", "staging")
+	cliErr, ok := err.(*clierrors.CLIError)
+	if !ok {
+		t.Fatalf("error type = %T, want *clierrors.CLIError", err)
+	}
+	if !strings.Contains(cliErr.Suggestion, "Development, Production") {
+		t.Fatalf("Suggestion = %q, want the valid environment names", cliErr.Suggestion)
+	}
+}
+
+func TestIsGitAuthError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{
+			"command error",
+			clierrors.WrapError("failed to clone", &git.CommandError{
+				Action: "git clone",
+				Output: "ERROR: Repository not found.\nfatal: Could not read from remote repository.",
+				Err:    stderrors.New("exit status 128"),
+			}),
+			true,
+		},
+		{
+			"exit error stderr",
+			fmt.Errorf("git fetch: %w", &exec.ExitError{Stderr: []byte("fatal: Authentication failed for 'https://github.com/org/repo.git/'")}),
+			true,
+		},
+		{
+			"unrelated failure",
+			&git.CommandError{Action: "git clone", Output: "fatal: destination path 'app' already exists", Err: stderrors.New("exit status 128")},
+			false,
+		},
+		{"bare exit status", stderrors.New("exit status 128"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isGitAuthError(tt.err); got != tt.want {
+				t.Errorf("isGitAuthError() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"github.com/major-technology/cli/middleware"
 	"github.com/major-technology/cli/singletons"
 	"github.com/spf13/cobra"
 )
@@ -15,6 +16,10 @@ var infoCmd = &cobra.Command{
 	Use:   "info",
 	Short: "Display information about the current application",
 	Long:  `Display information about the application in the current directory, including the application ID, deploy status, and URL.`,
+	PreRunE: middleware.Compose(
+		middleware.CheckGitInstalled,
+		middleware.CheckInGitRepository,
+	),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return runInfo(cmd)
 	},
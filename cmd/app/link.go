@@ -2,23 +2,39 @@ package app
 
 import (
 	"fmt"
+	"regexp"
 
+	"github.com/charmbracelet/huh"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/major-technology/cli/clients/api"
+	"github.com/major-technology/cli/clients/git"
 	mjrToken "github.com/major-technology/cli/clients/token"
 	"github.com/major-technology/cli/cmd/user"
 	"github.com/major-technology/cli/errors"
+	"github.com/major-technology/cli/middleware"
 	"github.com/major-technology/cli/singletons"
 	"github.com/major-technology/cli/utils"
 	"github.com/spf13/cobra"
 )
 
-// linkCmd represents the link command (hidden - used by install script)
+var flagLinkYes bool
+
+// linkCmd represents the link command (also used by the install script)
 var linkCmd = &cobra.Command{
-	Use:    "link [application-id]",
-	Short:  "Link and run an application locally",
-	Long:   `Links an application by ID - logs in if needed, clones the repository, and starts the development server.`,
-	Args:   cobra.ExactArgs(1),
-	Hidden: true,
+	Use:   "link <application-id>",
+	Short: "Link and run an application locally",
+	Long: `Set up an application from its ID in one step: log in if needed, clone the
+repository, generate .env and MCP configs, then start the development server.
+
+  major app link 123e4567-e89b-12d3-a456-426614174000
+
+The application is cloned into a directory named after it. If that directory is
+already a checkout of the application, it is pulled instead. You're asked to
+confirm before anything is cloned; pass --yes to skip the prompt.`,
+	Args: cobra.ExactArgs(1),
+	PreRunE: middleware.Compose(
+		middleware.CheckGitInstalled,
+	),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return runLink(cmd, args[0])
 	},
@@ -26,9 +42,50 @@ var linkCmd = &cobra.Command{
 
 func init() {
 	Cmd.AddCommand(linkCmd)
+	linkCmd.Flags().BoolVarP(&flagLinkYes, "yes", "y", false, "Clone without asking for confirmation")
+}
+
+// applicationIDPattern matches application IDs, which are UUIDs
+var applicationIDPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// validateApplicationID rejects anything that isn't shaped like an application ID,
+// so typos fail before logging in or calling the API
+func validateApplicationID(id string) error {
+	if applicationIDPattern.MatchString(id) {
+		return nil
+	}
+	return &errors.CLIError{
+		Title:      fmt.Sprintf("'%s' is not a valid application ID", id),
+		Suggestion: "Application IDs look like 123e4567-e89b-12d3-a456-426614174000. Run 'major app list' to see them, or 'major app clone <name>' to pick an application by name.",
+	}
+}
+
+// linkInfoError explains a failed link-info lookup. Link is usually run from a
+// copied install command, so not-found and no-access responses name the ID and say
+// what to check rather than surfacing a bare API error.
+func linkInfoError(applicationID string, err error) error {
+	switch {
+	case api.IsApplicationNotFound(err):
+		return &errors.CLIError{
+			Title:      errors.ErrorApplicationNotFound.Title,
+			Suggestion: fmt.Sprintf("No application has the ID %s. Check that the link or install command was copied in full, or run 'major app list' to see your applications.", applicationID),
+			Err:        fmt.Errorf("%s: %w", applicationID, errors.ErrorApplicationNotFound),
+		}
+	case api.IsNoApplicationAccess(err):
+		return &errors.CLIError{
+			Title:      errors.ErrorNoApplicationAccess.Title,
+			Suggestion: "You're logged in, but not to an account with access to this application. Ask its owner to invite you, or run 'major user login' to switch accounts.",
+			Err:        fmt.Errorf("%s: %w", applicationID, errors.ErrorNoApplicationAccess),
+		}
+	}
+	return errors.WrapError("failed to get application info", err)
 }
 
 func runLink(cmd *cobra.Command, applicationID string) error {
+	if err := validateApplicationID(applicationID); err != nil {
+		return err
+	}
+
 	// Step 1: Check if user is logged in, login if not
 	_, err := mjrToken.GetToken()
 	if err != nil {
@@ -43,7 +100,7 @@ func runLink(cmd *cobra.Command, applicationID string) error {
 
 	appInfo, err := apiClient.GetApplicationForLink(applicationID)
 	if err != nil {
-		return errors.WrapError("failed to get application info", err)
+		return linkInfoError(applicationID, err)
 	}
 
 	cmd.Printf("Found application: %s\n", appInfo.Name)
@@ -54,6 +111,23 @@ func runLink(cmd *cobra.Command, applicationID string) error {
 
 	// Step 3: Clone the repository or ensure existing directory is properly set up
 	workingDir := sanitizeDirName(appInfo.Name)
+	app := &api.ApplicationItem{
+		ID:            appInfo.ApplicationID,
+		Name:          appInfo.Name,
+		CloneURLSSH:   appInfo.CloneURLSSH,
+		CloneURLHTTPS: appInfo.CloneURLHTTPS,
+	}
+	if app.ID == "" {
+		app.ID = applicationID
+	}
+
+	// Never pull this application over another one's checkout
+	if err := checkDirMatchesApp(workingDir, app); err != nil {
+		return err
+	}
+	if err := confirmLink(cmd, workingDir, appInfo.Name); err != nil {
+		return err
+	}
 
 	// Ensure the directory is a properly configured git repository
 	gitErr := ensureGitRepository(cmd, workingDir, appInfo.CloneURLSSH, appInfo.CloneURLHTTPS)
@@ -84,17 +158,42 @@ func runLink(cmd *cobra.Command, applicationID string) error {
 	cmd.Printf("✓ Generated .env file at: %s\n", envFilePath)
 
 	// Generate .mcp.json for Claude Code
-	if _, err := utils.GenerateMcpConfig(workingDir, envVars); err != nil {
-		cmd.Printf("Warning: Failed to generate .mcp.json: %v\n", err)
-	} else {
-		cmd.Println("✓ Generated .mcp.json for Claude Code")
-	}
+	generateMcpConfig(cmd, workingDir, envVars)
 
 	// Step 5: Print success and run start
 	printLinkSuccessMessage(cmd, workingDir, appInfo.Name)
 
 	// Step 6: Run pnpm install and pnpm dev in the target directory
-	return RunStartInDir(cmd, workingDir)
+	return RunStartInDir(cmd, workingDir, envVars)
+}
+
+// confirmLink tells the user when dir already holds the application, and otherwise
+// asks before cloning into it. The prompt is skipped with --yes or without a terminal.
+func confirmLink(cmd *cobra.Command, dir, appName string) error {
+	if git.IsGitRepositoryDir(dir) {
+		cmd.Printf("'%s' already holds %s, updating it instead of cloning\n", dir, appName)
+		return nil
+	}
+	if flagLinkYes || !utils.IsInteractiveTerminal() {
+		return nil
+	}
+
+	confirmed := true
+	form := huh.NewForm(
+		huh.NewGroup(
+			huh.NewConfirm().
+				Title(fmt.Sprintf("Clone %s into ./%s?", appName, dir)).
+				Description("The development server starts once setup finishes.").
+				Value(&confirmed),
+		),
+	)
+	if err := form.Run(); err != nil {
+		return errors.WrapError("failed to confirm link", err)
+	}
+	if !confirmed {
+		return errors.ErrorOperationCancelled
+	}
+	return nil
 }
 
 func printLinkSuccessMessage(cmd *cobra.Command, dir, appName string) {
@@ -0,0 +1,58 @@
+package app
+
+import (
+	stderrors "errors"
+	"testing"
+
+	clierrors "github.com/major-technology/cli/errors"
+)
+
+func TestValidateApplicationID(t *testing.T) {
+	valid := []string{
+		"123e4567-e89b-12d3-a456-426614174000",
+		"123E4567-E89B-12D3-A456-426614174000",
+	}
+	for _, id := range valid {
+		if err := validateApplicationID(id); err != nil {
+			t.Errorf("validateApplicationID(%q) = %v, want nil", id, err)
+		}
+	}
+
+	invalid := []string{
+		"",
+		"my-app",
+		"123e4567e89b12d3a456426614174000",
+		"123e4567-e89b-12d3-a456-42661417400",
+		" 123e4567-e89b-12d3-a456-426614174000",
+	}
+	for _, id := range invalid {
+		if err := validateApplicationID(id); err == nil {
+			t.Errorf("validateApplicationID(%q) = nil, want an error", id)
+		}
+	}
+}
+
+func TestLinkInfoError(t *testing.T) {
+	const id = "123e4567-e89b-12d3-a456-426614174000"
+
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{name: "not found", err: clierrors.ErrorApplicationNotFoundAPI, want: clierrors.ErrorApplicationNotFound},
+		{name: "no access", err: clierrors.ErrorNoApplicationAccess, want: clierrors.ErrorNoApplicationAccess},
+	}
+
+	for _, tt := range tests {
+		err := linkInfoError(id, tt.err)
+		if !stderrors.Is(err, tt.want) {
+			t.Errorf("%s: linkInfoError = %v, want it to wrap %v", tt.name, err, tt.want)
+		}
+	}
+
+	other := stderrors.New("connection refused")
+	if err := linkInfoError(id, other); !stderrors.Is(err, other) {
+		t.Errorf("linkInfoError = %v, want it to wrap the original error", err)
+	}
+}
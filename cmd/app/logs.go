@@ -3,32 +3,41 @@ package app
 import (
 	"encoding/json"
 	"fmt"
+	"os"
 	"time"
 
+	xt "github.com/charmbracelet/x/term"
 	"github.com/major-technology/cli/clients/api"
 	"github.com/major-technology/cli/errors"
+	"github.com/major-technology/cli/middleware"
 	"github.com/major-technology/cli/singletons"
 	"github.com/spf13/cobra"
 )
 
 var (
 	flagLogsLimit     int
+	flagLogsTail      int
 	flagLogsSearch    string
 	flagLogsSince     string
 	flagLogsUntil     time.Time
 	flagLogsNextToken string
 	flagLogsJSON      bool
+	flagLogsRaw       bool
+	flagLogsNoColor   bool
 )
 
 var logsTimeFormats = []string{time.RFC3339Nano, time.RFC3339}
 
 func init() {
 	logsCmd.Flags().IntVar(&flagLogsLimit, "limit", 0, "Maximum number of log lines to return (1-5000, default 500)")
+	logsCmd.Flags().IntVar(&flagLogsTail, "tail", 0, "Show only the most recent n log lines, oldest first")
 	logsCmd.Flags().StringVar(&flagLogsSearch, "search", "", "Filter log lines by substring (case-sensitive)")
 	logsCmd.Flags().StringVar(&flagLogsSince, "since", "", "Show logs since a duration (e.g. 30m, 1h) or RFC3339 timestamp")
 	logsCmd.Flags().TimeVar(&flagLogsUntil, "until", time.Time{}, logsTimeFormats, "Show logs up until an RFC3339 timestamp")
 	logsCmd.Flags().StringVar(&flagLogsNextToken, "next-token", "", "Pagination cursor from a previous response")
 	logsCmd.Flags().BoolVar(&flagLogsJSON, "json", false, "Output in JSON format")
+	logsCmd.Flags().BoolVar(&flagLogsRaw, "raw", false, "Print log lines as-is instead of parsing levels and JSON fields")
+	logsCmd.Flags().BoolVar(&flagLogsNoColor, "no-color", false, "Don't color log levels (also set by NO_COLOR)")
 }
 
 var logsCmd = &cobra.Command{
@@ -37,7 +46,19 @@ var logsCmd = &cobra.Command{
 	Long: `Display logs for the application in the current directory.
 
 Logs are returned newest-first. When there are more logs than the limit,
-a pagination cursor is printed that can be passed back with --next-token.`,
+a pagination cursor is printed that can be passed back with --next-token.
+
+Use --tail n to show the n most recent lines in chronological order, like tail(1),
+and --since to limit how far back to look:
+
+  major app logs --since 10m --tail 100
+
+JSON log lines and lines with a severity word are shown with an aligned timestamp
+and a colored level. Use --raw to print lines exactly as the app wrote them.`,
+	PreRunE: middleware.Compose(
+		middleware.CheckGitInstalled,
+		middleware.CheckInGitRepository,
+	),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return runLogs(cmd)
 	},
@@ -49,6 +70,16 @@ func runLogs(cmd *cobra.Command) error {
 		return err
 	}
 
+	if flagLogsTail != 0 && flagLogsLimit != 0 {
+		return &errors.CLIError{
+			Title:      "Conflicting flags",
+			Suggestion: "--tail and --limit cannot be used together.",
+		}
+	}
+	if flagLogsTail < 0 || flagLogsTail > 5000 {
+		return fmt.Errorf("invalid --tail value %d, must be between 1 and 5000", flagLogsTail)
+	}
+
 	since, err := parseSinceFlag(flagLogsSince)
 	if err != nil {
 		return errors.WrapError("invalid --since value", err)
@@ -59,8 +90,14 @@ func runLogs(cmd *cobra.Command) error {
 		until = flagLogsUntil.UTC().Format(time.RFC3339Nano)
 	}
 
+	// The newest lines come first, so the tail is the first page of that size
+	limit := flagLogsLimit
+	if flagLogsTail > 0 {
+		limit = flagLogsTail
+	}
+
 	req := api.GetApplicationLogsRequest{
-		Limit:     flagLogsLimit,
+		Limit:     limit,
 		Search:    flagLogsSearch,
 		NextToken: flagLogsNextToken,
 		Since:     since,
@@ -82,8 +119,17 @@ func runLogs(cmd *cobra.Command) error {
 		return nil
 	}
 
-	for _, entry := range resp.Logs {
-		fmt.Fprintf(cmd.OutOrStdout(), "%s  %s\n", entry.Ts, entry.Log)
+	entries := resp.Logs
+	if flagLogsTail > 0 {
+		entries = oldestFirst(entries)
+	}
+	color := !flagLogsNoColor && os.Getenv("NO_COLOR") == "" && xt.IsTerminal(os.Stdout.Fd())
+	for _, entry := range entries {
+		if flagLogsRaw {
+			fmt.Fprintf(cmd.OutOrStdout(), "%s  %s\n", entry.Ts, entry.Log)
+		} else {
+			fmt.Fprintln(cmd.OutOrStdout(), formatLogEntry(entry.Ts, entry.Log, color))
+		}
 	}
 
 	if resp.NextToken != "" {
@@ -93,6 +139,15 @@ func runLogs(cmd *cobra.Command) error {
 	return nil
 }
 
+// oldestFirst returns newest-first log entries in chronological order
+func oldestFirst(entries []api.LogEntry) []api.LogEntry {
+	reversed := make([]api.LogEntry, len(entries))
+	for i, entry := range entries {
+		reversed[len(entries)-1-i] = entry
+	}
+	return reversed
+}
+
 // parseSinceFlag accepts either a Go duration (e.g. "30m", "1h") relative to
 // now, or an RFC3339 timestamp. Returns an RFC3339 string suitable for the API.
 func parseSinceFlag(s string) (string, error) {
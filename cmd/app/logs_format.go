@@ -0,0 +1,176 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// logLine is a log entry split into the parts app logs renders separately
+type logLine struct {
+	level   string // DEBUG, INFO, WARN, ERROR, FATAL, or empty if unknown
+	message string
+}
+
+var (
+	// leadingTimestampPattern matches a timestamp the app wrote at the start of its line,
+	// which duplicates the entry's own timestamp
+	leadingTimestampPattern = regexp.MustCompile(`^\[?\d{4}-\d{2}-\d{2}[T ]\d{2}:\d{2}:\d{2}(?:[.,]\d+)?(?:Z|[+-]\d{2}:?\d{2})?\]?\s+`)
+
+	// levelPattern finds a severity word near the start of a plain text line
+	levelPattern = regexp.MustCompile(`(?i)\b(trace|debug|info|warn|warning|error|err|fatal|panic|critical)\b`)
+)
+
+// levelScanWidth is how far into a plain text line to look for a severity word
+const levelScanWidth = 40
+
+// parseLogLine extracts the severity and message from JSON logs and plain text lines
+func parseLogLine(raw string) logLine {
+	trimmed := strings.TrimSpace(raw)
+	if strings.HasPrefix(trimmed, "{") {
+		if line, ok := parseJSONLogLine(trimmed); ok {
+			return line
+		}
+	}
+
+	message := leadingTimestampPattern.ReplaceAllString(raw, "")
+
+	prefix := message
+	if len(prefix) > levelScanWidth {
+		prefix = prefix[:levelScanWidth]
+	}
+	var level string
+	if match := levelPattern.FindStringSubmatch(prefix); match != nil {
+		level = normalizeLogLevel(match[1])
+	}
+
+	return logLine{level: level, message: message}
+}
+
+// parseJSONLogLine reads a structured log line, e.g. from pino or zap. The message
+// is followed by the remaining fields as sorted key=value pairs.
+func parseJSONLogLine(raw string) (logLine, bool) {
+	var fields map[string]any
+	if err := json.Unmarshal([]byte(raw), &fields); err != nil {
+		return logLine{}, false
+	}
+
+	var line logLine
+	for _, key := range []string{"level", "severity", "lvl"} {
+		switch v := fields[key].(type) {
+		case string:
+			line.level = normalizeLogLevel(v)
+		case float64:
+			line.level = pinoLogLevel(v)
+		default:
+			continue
+		}
+		delete(fields, key)
+		break
+	}
+
+	for _, key := range []string{"msg", "message"} {
+		if v, ok := fields[key].(string); ok {
+			line.message = v
+			delete(fields, key)
+			break
+		}
+	}
+
+	// The entry already carries a timestamp
+	for _, key := range []string{"time", "timestamp", "ts"} {
+		delete(fields, key)
+	}
+
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys)+1)
+	if line.message != "" {
+		parts = append(parts, line.message)
+	}
+	for _, key := range keys {
+		value, err := json.Marshal(fields[key])
+		if err != nil {
+			continue
+		}
+		parts = append(parts, fmt.Sprintf("%s=%s", key, value))
+	}
+	line.message = strings.Join(parts, " ")
+
+	return line, true
+}
+
+// normalizeLogLevel maps the many spellings of a severity onto the levels app logs shows
+func normalizeLogLevel(level string) string {
+	switch strings.ToLower(level) {
+	case "trace", "debug":
+		return "DEBUG"
+	case "info":
+		return "INFO"
+	case "warn", "warning":
+		return "WARN"
+	case "error", "err":
+		return "ERROR"
+	case "fatal", "panic", "critical":
+		return "FATAL"
+	default:
+		return ""
+	}
+}
+
+// pinoLogLevel maps pino's numeric levels (10 trace through 60 fatal)
+func pinoLogLevel(level float64) string {
+	switch {
+	case level >= 60:
+		return "FATAL"
+	case level >= 50:
+		return "ERROR"
+	case level >= 40:
+		return "WARN"
+	case level >= 30:
+		return "INFO"
+	case level > 0:
+		return "DEBUG"
+	default:
+		return ""
+	}
+}
+
+// logLevelColors reuses the palette of the deploy status display
+var logLevelColors = map[string]string{
+	"DEBUG": "8",   // Gray
+	"INFO":  "12",  // Blue
+	"WARN":  "214", // Orange
+	"ERROR": "196", // Red
+	"FATAL": "196", // Red
+}
+
+// formatLogEntry renders an entry as an aligned local timestamp, severity and message,
+// coloring the severity when color is set
+func formatLogEntry(ts, raw string, color bool) string {
+	timestamp := ts
+	if t, err := time.Parse(time.RFC3339Nano, ts); err == nil {
+		timestamp = t.Local().Format("2006-01-02 15:04:05.000")
+	}
+
+	line := parseLogLine(raw)
+	level := fmt.Sprintf("%-5s", line.level)
+
+	if color {
+		timestamp = lipgloss.NewStyle().Foreground(lipgloss.Color("8")).Render(timestamp)
+		if c, ok := logLevelColors[line.level]; ok {
+			level = lipgloss.NewStyle().Foreground(lipgloss.Color(c)).Bold(true).Render(level)
+		}
+	}
+
+	return fmt.Sprintf("%s  %s  %s", timestamp, level, line.message)
+}
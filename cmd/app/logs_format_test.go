@@ -0,0 +1,66 @@
+package app
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseLogLine(t *testing.T) {
+	tests := []struct {
+		raw         string
+		wantLevel   string
+		wantMessage string
+	}{
+		{
+			raw:         `{"level":"error","msg":"query failed","table":"users","time":"2025-01-01T00:00:00Z"}`,
+			wantLevel:   "ERROR",
+			wantMessage: `query failed table="users"`,
+		},
+		{
+			raw:         `{"level":40,"msg":"slow request","ms":1200}`,
+			wantLevel:   "WARN",
+			wantMessage: "slow request ms=1200",
+		},
+		{
+			raw:         `{"severity":"INFO","message":"started"}`,
+			wantLevel:   "INFO",
+			wantMessage: "started",
+		},
+		{
+			raw:         "2025-01-01T12:00:00.123Z WARN disk almost full",
+			wantLevel:   "WARN",
+			wantMessage: "WARN disk almost full",
+		},
+		{
+			raw:         "[2025-01-01 12:00:00] error: connection refused",
+			wantLevel:   "ERROR",
+			wantMessage: "error: connection refused",
+		},
+		{
+			raw:         "GET /api/health 200",
+			wantLevel:   "",
+			wantMessage: "GET /api/health 200",
+		},
+		{
+			raw:         "{not json",
+			wantLevel:   "",
+			wantMessage: "{not json",
+		},
+	}
+
+	for _, tt := range tests {
+		got := parseLogLine(tt.raw)
+		if got.level != tt.wantLevel || got.message != tt.wantMessage {
+			t.Errorf("parseLogLine(%q) = {%q, %q}, want {%q, %q}", tt.raw, got.level, got.message, tt.wantLevel, tt.wantMessage)
+		}
+	}
+}
+
+func TestFormatLogEntryAlignsWithoutLevel(t *testing.T) {
+	withLevel := formatLogEntry("not-a-timestamp", "INFO ready", false)
+	withoutLevel := formatLogEntry("not-a-timestamp", "ready", false)
+
+	if got, want := strings.Index(withoutLevel, "ready"), strings.Index(withLevel, "INFO ready"); got != want {
+		t.Errorf("message starts at %d without a level, want %d to line up with leveled lines:\n%s\n%s", got, want, withLevel, withoutLevel)
+	}
+}
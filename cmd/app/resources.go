@@ -0,0 +1,207 @@
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/major-technology/cli/clients/api"
+	"github.com/major-technology/cli/clients/git"
+	"github.com/major-technology/cli/errors"
+	"github.com/major-technology/cli/middleware"
+	"github.com/major-technology/cli/singletons"
+	"github.com/major-technology/cli/utils"
+	"github.com/spf13/cobra"
+)
+
+// resourcesCmd represents the app resources command
+var resourcesCmd = &cobra.Command{
+	Use:   "resources",
+	Short: "Work with the resources connected to the application",
+	Long:  `Commands for working with the resources connected to the application in the current directory.`,
+	Args:  utils.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.Help()
+		return nil
+	},
+}
+
+// resourcesSyncCmd represents the app resources sync command
+var resourcesSyncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Regenerate .env, RESOURCES.md, and .mcp.json in one pass",
+	Long: `Regenerates the .env file, RESOURCES.md, and .mcp.json for the application in the current directory.
+Use --editors to also write MCP configs for Cursor (.cursor/mcp.json) or VS Code (.vscode/mcp.json).
+
+Run this after switching environments with 'major resource env' or after changing
+the application's resources in the web app.`,
+	PreRunE: middleware.Compose(
+		middleware.CheckGitInstalled,
+		middleware.CheckInGitRepository,
+	),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runResourcesSync(cmd)
+	},
+}
+
+// resourcesListCmd represents the app resources list command
+var resourcesListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the resources attached to the application",
+	Long: `List the resources attached to the application in the current directory.
+
+Use --output json to export them, e.g. to keep in version control. The file can be
+applied with 'major resource manage --from-file':
+
+  major app resources list --output json > resources.json`,
+	Args: utils.NoArgs,
+	PreRunE: middleware.Compose(
+		middleware.CheckLogin,
+		middleware.CheckGitInstalled,
+		middleware.CheckInGitRepository,
+	),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runResourcesList(cmd)
+	},
+}
+
+var flagResourcesOutput string
+
+func init() {
+	resourcesCmd.AddCommand(resourcesSyncCmd)
+	resourcesCmd.AddCommand(resourcesListCmd)
+
+	resourcesListCmd.Flags().StringVar(&flagResourcesOutput, "output", "text", "Output format: text or json")
+
+	resourcesSyncCmd.Flags().StringVar(&flagEnv, "env", "", "Generate .env for this environment (by name) without switching to it")
+	resourcesSyncCmd.Flags().StringSliceVar(&flagEditors, "editors", []string{"claude"}, "AI editors to write MCP configs for (claude, cursor, vscode)")
+	resourcesSyncCmd.Flags().BoolVar(&flagNoGitignore, "no-gitignore", false, "Don't add generated MCP configs to .gitignore")
+}
+
+func runResourcesSync(cmd *cobra.Command) error {
+	editors, err := utils.ParseMcpEditors(flagEditors)
+	if err != nil {
+		return errors.WrapError("invalid --editors value", err)
+	}
+
+	gitRoot, err := git.GetRepoRoot()
+	if err != nil {
+		return errors.ErrorNotInGitRepository
+	}
+
+	envSnapshot := snapshotFile(filepath.Join(gitRoot, ".env"))
+	resourcesSnapshot := snapshotFile(filepath.Join(gitRoot, "RESOURCES.md"))
+	mcpSnapshots := make([]fileSnapshot, len(editors))
+	for i, editor := range editors {
+		mcpSnapshots[i] = snapshotFile(filepath.Join(gitRoot, editor.ConfigPath))
+	}
+
+	_, envVars, err := generateEnvFile(gitRoot)
+	if err != nil {
+		return errors.WrapError("failed to generate .env file", err)
+	}
+
+	_, resourceCount, err := utils.GenerateResourcesFile(gitRoot)
+	if err != nil {
+		return errors.WrapError("failed to generate RESOURCES.md file", err)
+	}
+
+	cmd.Printf("✓ .env %s (%d variables)\n", envSnapshot.changeSummary(), len(envVars))
+	cmd.Printf("✓ RESOURCES.md %s (%d resources)\n", resourcesSnapshot.changeSummary(), resourceCount)
+
+	for i, editor := range editors {
+		_, mcpErr := utils.GenerateEditorMcpConfig(gitRoot, envVars, editor, utils.McpConfigOptions{
+			SkipGitignore: flagNoGitignore,
+		})
+		switch {
+		case stderrors.Is(mcpErr, utils.ErrMcpEnvVarsMissing):
+			cmd.Println("- MCP config skipped (application env doesn't include MCP variables)")
+			return nil
+		case mcpErr != nil:
+			cmd.Printf("Warning: Failed to generate %s: %v\n", editor.ConfigPath, mcpErr)
+		default:
+			cmd.Printf("✓ %s %s\n", editor.ConfigPath, mcpSnapshots[i].changeSummary())
+		}
+	}
+
+	return nil
+}
+
+func runResourcesList(cmd *cobra.Command) error {
+	if flagResourcesOutput != "text" && flagResourcesOutput != "json" {
+		return fmt.Errorf("invalid --output value %q, must be one of: text, json", flagResourcesOutput)
+	}
+
+	appInfo, err := utils.GetApplicationInfo("")
+	if err != nil {
+		return errors.WrapError("failed to identify application", err)
+	}
+
+	resp, err := singletons.GetAPIClient().GetApplicationResources(appInfo.ApplicationID)
+	if err != nil {
+		return errors.WrapError("failed to get application resources", err)
+	}
+
+	// Always emit an array, so an app without resources exports as []
+	resources := resp.Resources
+	if resources == nil {
+		resources = []api.ResourceItem{}
+	}
+
+	if flagResourcesOutput == "json" {
+		data, err := json.MarshalIndent(resources, "", "  ")
+		if err != nil {
+			return errors.WrapError("failed to marshal JSON", err)
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(data))
+		return nil
+	}
+
+	if len(resources) == 0 {
+		cmd.Println("No resources attached. Run 'major resource manage' to add some.")
+		return nil
+	}
+
+	cmd.Println("\nAttached Resources:")
+	cmd.Println("-------------------")
+	for _, r := range resources {
+		cmd.Printf("• %s (%s)  %s\n", r.Name, r.Type, r.ID)
+	}
+	cmd.Println()
+	return nil
+}
+
+// fileSnapshot captures a file's contents before it is regenerated so the
+// result can be reported as created, updated, or unchanged.
+type fileSnapshot struct {
+	path    string
+	existed bool
+	content []byte
+}
+
+// snapshotFile reads the current contents of path. A missing file is recorded
+// as not existing rather than treated as an error.
+func snapshotFile(path string) fileSnapshot {
+	content, err := os.ReadFile(path)
+	return fileSnapshot{
+		path:    path,
+		existed: err == nil,
+		content: content,
+	}
+}
+
+// changeSummary compares the snapshot against the file currently on disk.
+func (s fileSnapshot) changeSummary() string {
+	current, _ := os.ReadFile(s.path)
+	switch {
+	case !s.existed:
+		return "created"
+	case bytes.Equal(s.content, current):
+		return "unchanged"
+	default:
+		return "updated"
+	}
+}
@@ -1,12 +1,22 @@
 package app
 
 import (
+	"context"
+	"fmt"
+	"net"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/major-technology/cli/clients/git"
+	"github.com/major-technology/cli/clients/process"
 	"github.com/major-technology/cli/errors"
+	"github.com/major-technology/cli/middleware"
+	"github.com/major-technology/cli/singletons"
 	"github.com/major-technology/cli/utils"
 	"github.com/spf13/cobra"
 )
@@ -15,13 +25,50 @@ import (
 var startCmd = &cobra.Command{
 	Use:   "start",
 	Short: "Start the application locally",
-	Long:  `Runs pnpm install and pnpm dev to set up dependencies and start the development server.`,
+	Long: `Runs pnpm install and pnpm dev to set up dependencies and start the development server.
+
+Use --port and --host to run the server somewhere other than the framework's default,
+e.g. to run several apps at once.
+
+With --watch, the application's env is polled while the server runs, and .env and the
+MCP configs are regenerated when it changes on the server. The env is then not passed
+to pnpm dev directly, so the dev server reads the regenerated .env.`,
+	PreRunE: middleware.Compose(
+		middleware.CheckGitInstalled,
+		middleware.CheckInGitRepository,
+	),
 	RunE: func(cobraCmd *cobra.Command, args []string) error {
 		return runStart(cobraCmd)
 	},
 }
 
+func init() {
+	startCmd.Flags().StringVar(&flagEnv, "env", "", "Generate .env for this environment (by name) without switching to it")
+	startCmd.Flags().StringSliceVar(&flagEditors, "editors", []string{"claude"}, "AI editors to write MCP configs for (claude, cursor, vscode)")
+	startCmd.Flags().BoolVar(&flagNoGitignore, "no-gitignore", false, "Don't add generated MCP configs to .gitignore")
+	startCmd.Flags().BoolVar(&flagStartWatch, "watch", false, "Regenerate .env while the server runs when the application's env changes")
+	startCmd.Flags().IntVar(&flagStartPort, "port", 0, "Port for the development server (defaults to the framework's)")
+	startCmd.Flags().StringVar(&flagStartHost, "host", "", "Host or address for the development server to listen on")
+	startCmd.Flags().BoolVar(&flagStartNoEnvInject, "no-env-inject", false, "Don't pass the application's env to pnpm dev; rely on the template loading .env (implied by --watch)")
+	startCmd.Flags().DurationVar(&flagStartWatchInterval, "watch-interval", defaultEnvWatchInterval, "How often --watch checks the application's env")
+}
+
+var (
+	flagStartWatch         bool
+	flagStartWatchInterval time.Duration
+	flagStartPort          int
+	flagStartHost          string
+	flagStartNoEnvInject   bool
+)
+
 func runStart(cobraCmd *cobra.Command) error {
+	if _, err := utils.ParseMcpEditors(flagEditors); err != nil {
+		return errors.WrapError("invalid --editors value", err)
+	}
+	if flagStartPort < 0 || flagStartPort > 65535 {
+		return fmt.Errorf("invalid --port value %d, must be between 1 and 65535", flagStartPort)
+	}
+
 	// Check if local branch is behind origin/main
 	isBehind, count, err := git.IsBehindRemote()
 	if err != nil {
@@ -37,22 +84,87 @@ func runStart(cobraCmd *cobra.Command) error {
 	}
 
 	// Generate .mcp.json for Claude Code
-	if _, err := utils.GenerateMcpConfig("", envVars); err != nil {
-		cobraCmd.Printf("Warning: Failed to generate .mcp.json: %v\n", err)
-	}
+	generateMcpConfig(cobraCmd, "", envVars)
 
 	// Generate theme files (check for changes)
 	if err := handleThemeSync(cobraCmd); err != nil {
 		cobraCmd.Printf("Warning: Failed to sync theme files: %v\n", err)
 	}
 
+	if flagStartWatch {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		watcher, err := newStartEnvWatcher(cobraCmd)
+		if err != nil {
+			return err
+		}
+		go watcher.run(ctx, envVars)
+		cobraCmd.Printf("Watching the application's env for changes every %s\n", flagStartWatchInterval)
+	}
+
 	// Run start in current directory
-	return RunStartInDir(cobraCmd, "")
+	return RunStartInDir(cobraCmd, "", devServerInjectedEnv(envVars, flagStartWatch, flagStartNoEnvInject))
+}
+
+// devServerInjectedEnv returns the env to pass to pnpm dev. Nothing is injected with
+// --no-env-inject, or with --watch: Vite and Next.js prefer the process env over
+// .env, so injected values would hide the .env rewrites --watch makes.
+func devServerInjectedEnv(envVars map[string]string, watch, noInject bool) map[string]string {
+	if watch || noInject {
+		return nil
+	}
+	return envVars
+}
+
+// newStartEnvWatcher builds the --watch poller for the application in the current directory
+func newStartEnvWatcher(cobraCmd *cobra.Command) (*envWatcher, error) {
+	if flagStartWatchInterval <= 0 {
+		return nil, fmt.Errorf("invalid --watch-interval %s, must be positive", flagStartWatchInterval)
+	}
+
+	applicationID, orgID, _, err := getApplicationAndOrgIDFromDir("")
+	if err != nil {
+		return nil, errors.WrapError("failed to get application ID", err)
+	}
+
+	var environmentID string
+	if flagEnv != "" {
+		environmentID, err = resolveEnvironmentID(applicationID, flagEnv)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	gitRoot, err := git.GetRepoRoot()
+	if err != nil {
+		return nil, errors.WrapError("failed to get git repository root", err)
+	}
+
+	return &envWatcher{
+		interval: flagStartWatchInterval,
+		debounce: envWatchDebounce,
+		fetch: func() (map[string]string, error) {
+			return singletons.GetAPIClient().GetApplicationEnvForEnvironment(orgID, applicationID, environmentID)
+		},
+		apply: func(envVars map[string]string) error {
+			if err := utils.WriteFileAtomic(filepath.Join(gitRoot, ".env"), []byte(utils.FormatEnvFile(envVars)), 0600); err != nil {
+				return errors.WrapError("failed to write .env file", err)
+			}
+			generateMcpConfig(cobraCmd, gitRoot, envVars)
+			cobraCmd.Println("✓ Application env changed on the server; regenerated .env")
+			return nil
+		},
+		warn: func(err error) {
+			cobraCmd.Printf("Warning: Failed to check the application's env: %v\n", err)
+		},
+	}, nil
 }
 
 // RunStartInDir changes to the specified directory and runs pnpm install and pnpm dev.
-// If dir is empty, it uses the current directory.
-func RunStartInDir(cmd *cobra.Command, dir string) error {
+// If dir is empty, it uses the current directory. envVars are passed to pnpm dev so the
+// app gets its configuration even if the template doesn't load .env itself.
+func RunStartInDir(cmd *cobra.Command, dir string, envVars map[string]string) error {
 	// Change to the target directory if specified
 	if dir != "" {
 		absDir, err := filepath.Abs(dir)
@@ -68,7 +180,7 @@ func RunStartInDir(cmd *cobra.Command, dir string) error {
 
 	// Run pnpm install
 	cmd.Println("Running pnpm install...")
-	installCmd := exec.Command("pnpm", "install")
+	installCmd := process.CommandTimeout(process.LongTimeout, "pnpm", "install")
 	installCmd.Stdout = os.Stdout
 	installCmd.Stderr = os.Stderr
 	installCmd.Stdin = os.Stdin
@@ -79,16 +191,128 @@ func RunStartInDir(cmd *cobra.Command, dir string) error {
 
 	cmd.Println("✓ Dependencies installed")
 
+	// Check the port before starting, since a dev server that can't bind fails late and noisily
+	framework := utils.DetectFramework(".")
+	if err := checkDevServerPort(cmd, framework, flagStartHost, flagStartPort); err != nil {
+		return err
+	}
+
 	// Run pnpm dev
 	cmd.Println("\nStarting development server...")
-	devCmd := exec.Command("pnpm", "dev")
+	devCmd := exec.Command("pnpm", append([]string{"dev"}, devServerArgs(framework, flagStartHost, flagStartPort)...)...)
+	devCmd.Env = devServerEnv(os.Environ(), envVars)
+	if flagStartPort != 0 {
+		devCmd.Env = append(devCmd.Env, fmt.Sprintf("PORT=%d", flagStartPort))
+	}
+	if flagStartHost != "" {
+		devCmd.Env = append(devCmd.Env, "HOST="+flagStartHost)
+	}
 	devCmd.Stdout = os.Stdout
 	devCmd.Stderr = os.Stderr
 	devCmd.Stdin = os.Stdin
 
-	if err := devCmd.Run(); err != nil {
+	// Run in its own process group, so stopping the server also stops the node processes pnpm started
+	interrupted, err := process.RunGroup(devCmd)
+	if interrupted {
+		return errors.ErrorOperationCancelled
+	}
+	if err != nil {
 		return errors.WrapError("failed to run pnpm dev", err)
 	}
 
 	return nil
 }
+
+// devServerEnv adds envVars to the base environment. Variables already set in base
+// are kept, matching how dotenv loaders treat .env, so the shell can still override.
+func devServerEnv(base []string, envVars map[string]string) []string {
+	set := make(map[string]bool, len(base))
+	for _, kv := range base {
+		if key, _, ok := strings.Cut(kv, "="); ok {
+			set[key] = true
+		}
+	}
+
+	keys := make([]string, 0, len(envVars))
+	for key := range envVars {
+		if !set[key] {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	env := append([]string{}, base...)
+	for _, key := range keys {
+		env = append(env, key+"="+envVars[key])
+	}
+	return env
+}
+
+// defaultDevServerPorts are the ports each framework's dev server uses unless told otherwise
+var defaultDevServerPorts = map[string]int{
+	"nextjs": 3000,
+	"vite":   5173,
+}
+
+// devServerArgs returns the arguments that point the framework's dev server at host and port.
+// Custom dev scripts can read the PORT and HOST env vars instead.
+func devServerArgs(framework, host string, port int) []string {
+	var args []string
+	if port != 0 {
+		args = append(args, "--port", strconv.Itoa(port))
+	}
+	if host != "" {
+		if framework == "vite" {
+			args = append(args, "--host", host)
+		} else {
+			args = append(args, "--hostname", host)
+		}
+	}
+	return args
+}
+
+// checkDevServerPort fails if an explicit --port is taken, suggesting a free one. A busy
+// default port only warns, since the dev server may move to another port on its own.
+func checkDevServerPort(cmd *cobra.Command, framework, host string, port int) error {
+	explicit := port != 0
+	if !explicit {
+		port = defaultDevServerPorts[framework]
+	}
+	if port == 0 || portAvailable(host, port) {
+		return nil
+	}
+
+	suggestion := "Stop the other process using it, or pass --port to use a different port."
+	if free := nextFreePort(host, port); free != 0 {
+		suggestion = fmt.Sprintf("Stop the other process using it, or run 'major app start --port %d'.", free)
+	}
+
+	if explicit {
+		return &errors.CLIError{
+			Title:      fmt.Sprintf("Port %d is already in use", port),
+			Suggestion: suggestion,
+		}
+	}
+	cmd.Printf("Warning: Port %d is already in use. %s\n", port, suggestion)
+	return nil
+}
+
+// portAvailable reports whether a server could listen on host:port
+func portAvailable(host string, port int) bool {
+	ln, err := net.Listen("tcp", net.JoinHostPort(host, strconv.Itoa(port)))
+	if err != nil {
+		return false
+	}
+	ln.Close()
+	return true
+}
+
+// nextFreePort returns the first available port among the 20 after port, or 0 if all are taken
+func nextFreePort(host string, port int) int {
+	for candidate := port + 1; candidate <= port+20 && candidate <= 65535; candidate++ {
+		if portAvailable(host, candidate) {
+			return candidate
+		}
+	}
+	return 0
+}
@@ -0,0 +1,75 @@
+package app
+
+import (
+	"net"
+	"reflect"
+	"testing"
+)
+
+func TestDevServerArgs(t *testing.T) {
+	tests := []struct {
+		framework string
+		host      string
+		port      int
+		want      []string
+	}{
+		{framework: "nextjs", want: nil},
+		{framework: "nextjs", port: 3001, host: "0.0.0.0", want: []string{"--port", "3001", "--hostname", "0.0.0.0"}},
+		{framework: "vite", port: 5174, host: "0.0.0.0", want: []string{"--port", "5174", "--host", "0.0.0.0"}},
+		{framework: "vite", port: 5174, want: []string{"--port", "5174"}},
+	}
+
+	for _, tt := range tests {
+		if got := devServerArgs(tt.framework, tt.host, tt.port); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("devServerArgs(%q, %q, %d) = %v, want %v", tt.framework, tt.host, tt.port, got, tt.want)
+		}
+	}
+}
+
+func TestPortAvailable(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("can't listen: %v", err)
+	}
+	defer ln.Close()
+	port := ln.Addr().(*net.TCPAddr).Port
+
+	if portAvailable("127.0.0.1", port) {
+		t.Errorf("portAvailable(%d) = true while it is in use", port)
+	}
+	if free := nextFreePort("127.0.0.1", port); free <= port {
+		t.Errorf("nextFreePort(%d) = %d, want a later port", port, free)
+	}
+}
+
+func TestDevServerEnv(t *testing.T) {
+	base := []string{"PATH=/usr/bin", "API_URL=https://shell.example"}
+	envVars := map[string]string{
+		"API_URL":    "https://dotenv.example",
+		"DB_URL":     "postgres://db",
+		"APP_SECRET": "s3cret",
+	}
+
+	want := []string{"PATH=/usr/bin", "API_URL=https://shell.example", "APP_SECRET=s3cret", "DB_URL=postgres://db"}
+	if got := devServerEnv(base, envVars); !reflect.DeepEqual(got, want) {
+		t.Errorf("devServerEnv = %v, want %v", got, want)
+	}
+	if len(base) != 2 {
+		t.Errorf("base was modified: %v", base)
+	}
+}
+
+func TestDevServerInjectedEnv(t *testing.T) {
+	envVars := map[string]string{"API_KEY": "abc"}
+
+	if got := devServerInjectedEnv(envVars, false, false); !reflect.DeepEqual(got, envVars) {
+		t.Errorf("default: injected %v, want %v", got, envVars)
+	}
+	if got := devServerInjectedEnv(envVars, false, true); got != nil {
+		t.Errorf("--no-env-inject: injected %v, want nothing", got)
+	}
+	// The process env would take priority over the .env files --watch rewrites
+	if got := devServerInjectedEnv(envVars, true, false); got != nil {
+		t.Errorf("--watch: injected %v, want nothing", got)
+	}
+}
@@ -0,0 +1,85 @@
+package app
+
+import (
+	"context"
+	"maps"
+	"time"
+)
+
+const (
+	// defaultEnvWatchInterval is how often --watch polls the application's env
+	defaultEnvWatchInterval = 30 * time.Second
+
+	// envWatchDebounce is how long a changed env must stay the same before it is applied
+	envWatchDebounce = 5 * time.Second
+)
+
+// envWatcher regenerates local config while the dev server runs, whenever the
+// application's env changes on the server
+type envWatcher struct {
+	interval time.Duration
+	debounce time.Duration
+	fetch    func() (map[string]string, error)
+	apply    func(map[string]string) error
+	warn     func(error)
+}
+
+// run polls until ctx is done, starting from the env already written locally.
+// A change is applied only once it has stopped changing for w.debounce, so a
+// burst of edits in the web app rewrites .env once rather than on every poll.
+func (w *envWatcher) run(ctx context.Context, current map[string]string) {
+	for sleepContext(ctx, w.interval) {
+		env, err := w.fetch()
+		if err != nil {
+			w.warn(err)
+			continue
+		}
+		if maps.Equal(env, current) {
+			continue
+		}
+
+		env, ok := w.settle(ctx, env)
+		if !ok {
+			continue
+		}
+
+		if err := w.apply(env); err != nil {
+			w.warn(err)
+			continue
+		}
+		current = env
+	}
+}
+
+// settle re-fetches the env every w.debounce until two fetches agree. It returns
+// false if ctx ends or a fetch fails, in which case the next poll tries again.
+func (w *envWatcher) settle(ctx context.Context, env map[string]string) (map[string]string, bool) {
+	for {
+		if !sleepContext(ctx, w.debounce) {
+			return nil, false
+		}
+
+		next, err := w.fetch()
+		if err != nil {
+			w.warn(err)
+			return nil, false
+		}
+		if maps.Equal(next, env) {
+			return env, true
+		}
+		env = next
+	}
+}
+
+// sleepContext waits for d, returning false if ctx is done first
+func sleepContext(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
@@ -0,0 +1,87 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeEnvSource returns each env in turn, repeating the last one
+type fakeEnvSource struct {
+	mu    sync.Mutex
+	envs  []map[string]string
+	errAt int // 1-based fetch that fails, or 0
+	calls int
+}
+
+func (f *fakeEnvSource) fetch() (map[string]string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.calls++
+	if f.calls == f.errAt {
+		return nil, errors.New("network down")
+	}
+	i := f.calls - 1
+	if i >= len(f.envs) {
+		i = len(f.envs) - 1
+	}
+	return f.envs[i], nil
+}
+
+func runWatcher(t *testing.T, source *fakeEnvSource, initial map[string]string) []map[string]string {
+	t.Helper()
+
+	var mu sync.Mutex
+	var applied []map[string]string
+	w := &envWatcher{
+		interval: time.Millisecond,
+		debounce: time.Millisecond,
+		fetch:    source.fetch,
+		apply: func(env map[string]string) error {
+			mu.Lock()
+			defer mu.Unlock()
+			applied = append(applied, env)
+			return nil
+		},
+		warn: func(error) {},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	w.run(ctx, initial)
+
+	mu.Lock()
+	defer mu.Unlock()
+	return applied
+}
+
+func TestEnvWatcherAppliesSettledChangeOnce(t *testing.T) {
+	old := map[string]string{"API_KEY": "old"}
+	source := &fakeEnvSource{envs: []map[string]string{
+		old,
+		{"API_KEY": "editing"},
+		{"API_KEY": "new"},
+		{"API_KEY": "new"},
+	}}
+
+	applied := runWatcher(t, source, old)
+
+	if len(applied) != 1 {
+		t.Fatalf("applied %d times (%v), want once", len(applied), applied)
+	}
+	if applied[0]["API_KEY"] != "new" {
+		t.Errorf("applied %v, want the settled env", applied[0])
+	}
+}
+
+func TestEnvWatcherIgnoresUnchangedAndFailedFetches(t *testing.T) {
+	env := map[string]string{"API_KEY": "same"}
+	source := &fakeEnvSource{envs: []map[string]string{env}, errAt: 2}
+
+	if applied := runWatcher(t, source, env); len(applied) != 0 {
+		t.Errorf("applied %v, want nothing for an unchanged env", applied)
+	}
+}
@@ -0,0 +1,58 @@
+// Package cmdtest runs commands in tests and captures what they print, with the
+// API client and credential store swapped for in-memory fakes.
+package cmdtest
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/major-technology/cli/clients/api"
+	"github.com/major-technology/cli/singletons"
+	"github.com/spf13/cobra"
+	"github.com/zalando/go-keyring"
+)
+
+// Run executes cmd with args as if it were invoked from the command line, and
+// returns what it printed to its output and error streams. Like the real root
+// command, cobra's own error and usage printing is silenced, so the error is only
+// returned. Package-level flag variables keep their values between runs, so tests
+// that set flags should reset them.
+func Run(t testing.TB, cmd *cobra.Command, args ...string) (stdout, stderr string, err error) {
+	t.Helper()
+
+	root := cmd.Root()
+	var outBuf, errBuf bytes.Buffer
+	root.SetOut(&outBuf)
+	root.SetErr(&errBuf)
+	root.SetArgs(append(strings.Fields(cmd.CommandPath())[1:], args...))
+
+	silenceErrors, silenceUsage := root.SilenceErrors, root.SilenceUsage
+	root.SilenceErrors, root.SilenceUsage = true, true
+	defer func() {
+		root.SetOut(nil)
+		root.SetErr(nil)
+		root.SetArgs(nil)
+		root.SilenceErrors, root.SilenceUsage = silenceErrors, silenceUsage
+	}()
+
+	_, err = root.ExecuteC()
+	return outBuf.String(), errBuf.String(), err
+}
+
+// UseAPIClient installs client as the global API client until the test ends.
+// Fakes usually embed api.APIClient and implement only the calls under test.
+func UseAPIClient(t testing.TB, client api.APIClient) {
+	t.Helper()
+	prev := singletons.GetAPIClient()
+	singletons.SetAPIClient(client)
+	t.Cleanup(func() { singletons.SetAPIClient(prev) })
+}
+
+// UseMockKeyring replaces the system keyring with an empty in-memory one, so
+// credentials such as the default organization can be set without touching the
+// real keyring. It must be called before the test binary first reads a credential.
+func UseMockKeyring(t testing.TB) {
+	t.Helper()
+	keyring.MockInit()
+}
@@ -18,4 +18,5 @@ var Cmd = &cobra.Command{
 func init() {
 	Cmd.AddCommand(setEnvCmd)
 	Cmd.AddCommand(getEnvCmd)
+	Cmd.AddCommand(setCmd)
 }
@@ -0,0 +1,57 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/major-technology/cli/clients/config"
+	"github.com/spf13/cobra"
+)
+
+var setCmd = &cobra.Command{
+	Use:   "set <key> <value>",
+	Short: "Save a persistent CLI setting",
+	Long: `Save a setting in ~/.major/settings.json, applied to every command.
+
+Settings:
+  telemetry on|off  Allow non-essential features such as the optional upgrade
+                    notice (MAJOR_TELEMETRY overrides it). Required upgrades are
+                    still enforced; use --skip-version-check to skip those too`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runSet(cmd, args[0], args[1])
+	},
+}
+
+func runSet(cmd *cobra.Command, key, value string) error {
+	switch key {
+	case "telemetry":
+		enabled, err := parseOnOff(value)
+		if err != nil {
+			return fmt.Errorf("invalid telemetry value %q, must be one of: on, off", value)
+		}
+		if err := config.SaveSetting(key, enabled); err != nil {
+			return err
+		}
+		state := "off"
+		if enabled {
+			state = "on"
+		}
+		cmd.Printf("Telemetry turned %s\n", state)
+		return nil
+	default:
+		return fmt.Errorf("unknown setting %q, must be one of: telemetry", key)
+	}
+}
+
+// parseOnOff accepts on/off as well as the values strconv.ParseBool understands
+func parseOnOff(value string) (bool, error) {
+	switch strings.ToLower(value) {
+	case "on":
+		return true, nil
+	case "off":
+		return false, nil
+	}
+	return strconv.ParseBool(value)
+}
@@ -1,10 +1,10 @@
 package demo
 
 import (
+	stderrors "errors"
 	"fmt"
 	"os"
 	"path/filepath"
-	"strings"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/major-technology/cli/clients/api"
@@ -17,8 +17,14 @@ import (
 	"github.com/spf13/cobra"
 )
 
-var DemoCloneURLSSH = "git@github.com:major-technology/vite-api-usage-demo.git"
-var DemoCloneURLHTTPS = "https://github.com/major-technology/vite-api-usage-demo.git"
+// flagShallow clones only the latest template commit; its history is discarded on push anyway
+var flagShallow bool
+
+// flagGitProtocol forces the git transport (ssh, https, or auto-detect)
+var flagGitProtocol = utils.GitProtocolAuto
+
+// flagTemplate selects the demo template by ID or name, skipping the prompt
+var flagTemplate string
 
 // createCmd represents the create command
 var createCmd = &cobra.Command{
@@ -26,14 +32,26 @@ var createCmd = &cobra.Command{
 	Short: "Create a new demo application",
 	Long:  `Create a new demo application with a GitHub repository and the demo template.`,
 	PreRunE: middleware.Compose(
+		middleware.RequireOnline,
 		middleware.CheckLogin,
+		middleware.CheckGitInstalled,
 	),
 	RunE: func(cobraCmd *cobra.Command, args []string) error {
 		return runCreate(cobraCmd)
 	},
 }
 
-func runCreate(cobraCmd *cobra.Command) error {
+func init() {
+	createCmd.Flags().StringVar(&flagTemplate, "template", "", "Demo template ID or name (see 'major demo list')")
+	createCmd.Flags().StringVar(&flagGitProtocol, "git-protocol", utils.GitProtocolAuto, "Git transport: ssh, https, or auto to use SSH when it works with GitHub")
+	createCmd.Flags().BoolVar(&flagShallow, "shallow", true, "Clone only the latest commit of the demo template (use --shallow=false for full history)")
+}
+
+func runCreate(cobraCmd *cobra.Command) (err error) {
+	if err := utils.ValidateGitProtocol(flagGitProtocol); err != nil {
+		return err
+	}
+
 	// Get default org from keychain
 	orgID, orgName, err := mjrToken.GetDefaultOrg()
 	if err != nil {
@@ -42,6 +60,12 @@ func runCreate(cobraCmd *cobra.Command) error {
 
 	cobraCmd.Printf("Creating demo application in organization: %s\n\n", orgName)
 
+	// Pick the template before creating anything, so a bad --template fails early
+	template, err := selectDemoTemplate(cobraCmd, fetchDemoTemplates(orgID), flagTemplate)
+	if err != nil {
+		return err
+	}
+
 	// Get the API client
 	apiClient := singletons.GetAPIClient()
 
@@ -52,43 +76,51 @@ func runCreate(cobraCmd *cobra.Command) error {
 	}
 
 	cobraCmd.Printf("✓ Demo application created with ID: %s\n", createResp.ApplicationID)
-	cobraCmd.Printf("✓ Repository: %s\n", createResp.RepositoryName)
 
-	// Check if we have permissions to use SSH or HTTPS
-	useSSH := false
-	if utils.CanUseSSH() {
-		cobraCmd.Println("✓ SSH access detected")
-		useSSH = true
-	} else if createResp.CloneURLHTTPS != "" {
-		cobraCmd.Println("✓ Using HTTPS for git operations")
-		useSSH = false
-	} else {
-		return errors.ErrorNoValidCloneMethodAvailable
-	}
+	// The application exists from here on, so point any failure at finishing setup rather than creating again
+	defer func() {
+		if err != nil {
+			err = errors.ErrorAppSetupIncomplete(createResp.ApplicationID, "major app clone --app-id "+createResp.ApplicationID, err)
+		}
+	}()
+	cobraCmd.Printf("✓ Repository: %s\n", createResp.RepositoryName)
 
-	// Determine which clone URL to use
-	templateURL := DemoCloneURLHTTPS
-	if useSSH {
-		templateURL = DemoCloneURLSSH
+	// Pick SSH or HTTPS per --git-protocol, auto-detecting SSH access by default
+	cloneURL, cloneMethod, err := utils.SelectCloneURL(flagGitProtocol, createResp.CloneURLSSH, createResp.CloneURLHTTPS)
+	if err != nil {
+		return err
 	}
+	cobraCmd.Printf("✓ Using %s for git operations\n", cloneMethod)
 
-	cloneURL := createResp.CloneURLHTTPS
-	if useSSH {
-		cloneURL = createResp.CloneURLSSH
+	// Use the same transport for the template
+	templateURL := template.CloneURLHTTPS
+	if cloneMethod == "SSH" {
+		templateURL = template.CloneURLSSH
 	}
 
-	// Create a temporary directory for the template
-	tempDir, err := os.MkdirTemp("", "major-demo-template-*")
+	// Create the temporary directory in the working directory so the final move stays on one filesystem
+	tempDir, err := os.MkdirTemp(".", ".major-tmp-*")
 	if err != nil {
 		return errors.WrapError("failed to create temp directory", err)
 	}
 	defer os.RemoveAll(tempDir)
 
 	// Clone the hardcoded demo template repository
-	if err := git.Clone(templateURL, tempDir); err != nil {
+	cloneTemplate := git.Clone
+	if flagShallow {
+		cloneTemplate = git.CloneShallow
+	}
+	if err := cloneTemplate(templateURL, tempDir); err != nil {
 		return errors.WrapError("failed to clone demo template repository", err)
 	}
 
+	// A shallow clone can't be pushed to an empty repository, so start from a fresh root commit
+	if flagShallow {
+		if err := git.ResetHistory(tempDir, "Initial commit from demo template"); err != nil {
+			return errors.WrapError("failed to prepare demo template history", err)
+		}
+	}
+
 	cobraCmd.Println("✓ Demo template cloned")
 
 	// Remove the existing remote origin
@@ -137,7 +169,7 @@ func runCreate(cobraCmd *cobra.Command) error {
 
 	// Move the repository to the current directory
 	targetDir := filepath.Join(".", createResp.RepositoryName)
-	if err := os.Rename(tempDir, targetDir); err != nil {
+	if err := utils.MoveDir(tempDir, targetDir); err != nil {
 		return errors.WrapError("failed to move repository", err)
 	}
 
@@ -146,21 +178,26 @@ func runCreate(cobraCmd *cobra.Command) error {
 
 	// Add the demo resource to the project
 	if len(selectedResources) > 0 {
+		cobraCmd.Println("\nAdding demo resource to the project...")
+		// The repository already exists at this point, so keep going and let the user link resources later
 		if err := utils.AddResourcesToProject(cobraCmd, targetDir, selectedResources, createResp.ApplicationID); err != nil {
-			return errors.ErrorFailedToSelectResources
+			cobraCmd.Printf("Warning: Failed to add resources to the project: %v\n", err)
+			cobraCmd.Printf("Your application was still created. Run 'major resource manage' in ./%s to link resources later.\n", filepath.Base(targetDir))
 		}
 	}
 
 	// Generate .env file
 	cobraCmd.Println("\nGenerating .env file...")
-	envFilePath, envVars, err := generateEnvFile(targetDir, orgID, createResp.ApplicationID)
+	envFilePath, envVars, err := utils.GenerateEnvFile(targetDir, orgID, createResp.ApplicationID, utils.EnvFileOptions{})
 	if err != nil {
 		cobraCmd.Printf("Warning: Failed to generate .env file: %v\n", err)
 	} else {
 		cobraCmd.Printf("✓ Generated .env file at: %s\n", envFilePath)
 
 		// Generate .mcp.json for Claude Code
-		if _, err := utils.GenerateMcpConfig(targetDir, envVars); err != nil {
+		if _, err := utils.GenerateMcpConfig(targetDir, envVars); stderrors.Is(err, utils.ErrMcpEnvVarsMissing) {
+			cobraCmd.Println("Skipping .mcp.json: this application's env doesn't include MCP variables")
+		} else if err != nil {
 			cobraCmd.Printf("Warning: Failed to generate .mcp.json: %v\n", err)
 		} else {
 			cobraCmd.Println("✓ Generated .mcp.json for Claude Code")
@@ -233,30 +270,3 @@ func printSuccessMessage(cobraCmd *cobra.Command, appName string) {
 	cobraCmd.Println(successMsg)
 	cobraCmd.Println(box)
 }
-
-// generateEnvFile generates a .env file for the application in the specified directory.
-func generateEnvFile(targetDir, orgID, applicationID string) (string, map[string]string, error) {
-	apiClient := singletons.GetAPIClient()
-
-	envVars, err := apiClient.GetApplicationEnv(orgID, applicationID)
-	if err != nil {
-		return "", nil, errors.WrapError("failed to get environment variables", err)
-	}
-
-	// Create .env file path
-	envFilePath := filepath.Join(targetDir, ".env")
-
-	// Build the .env file content
-	var envContent strings.Builder
-	for key, value := range envVars {
-		envContent.WriteString(fmt.Sprintf("%s=%s\n", key, value))
-	}
-
-	// Write to .env file
-	err = os.WriteFile(envFilePath, []byte(envContent.String()), 0644)
-	if err != nil {
-		return "", nil, errors.WrapError("failed to write .env file", err)
-	}
-
-	return envFilePath, envVars, nil
-}
@@ -27,4 +27,5 @@ var Cmd = &cobra.Command{
 func init() {
 	// Add demo subcommands
 	Cmd.AddCommand(createCmd)
+	Cmd.AddCommand(listCmd)
 }
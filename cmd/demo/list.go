@@ -0,0 +1,139 @@
+package demo
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/huh"
+	"github.com/major-technology/cli/clients/api"
+	mjrToken "github.com/major-technology/cli/clients/token"
+	"github.com/major-technology/cli/errors"
+	"github.com/major-technology/cli/middleware"
+	"github.com/major-technology/cli/singletons"
+	"github.com/spf13/cobra"
+)
+
+var flagListJSON bool
+
+// listCmd represents the demo list command
+var listCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the available demo templates",
+	Long:  `List the demo templates 'major demo create --template' can start from.`,
+	PreRunE: middleware.Compose(
+		middleware.CheckLogin,
+	),
+	RunE: func(cobraCmd *cobra.Command, args []string) error {
+		return runList(cobraCmd)
+	},
+}
+
+func init() {
+	listCmd.Flags().BoolVar(&flagListJSON, "json", false, "Output in JSON format")
+}
+
+func runList(cobraCmd *cobra.Command) error {
+	orgID, _, err := mjrToken.GetDefaultOrg()
+	if err != nil {
+		return errors.ErrorNoOrganizationSelected
+	}
+
+	templates := fetchDemoTemplates(orgID)
+
+	if flagListJSON {
+		data, err := json.Marshal(templates)
+		if err != nil {
+			return errors.WrapError("failed to marshal JSON", err)
+		}
+		fmt.Fprintln(cobraCmd.OutOrStdout(), string(data))
+		return nil
+	}
+
+	cobraCmd.Println("\nDemo Templates:")
+	cobraCmd.Println("-------------------")
+	for _, t := range templates {
+		line := fmt.Sprintf("• %s (%s)", t.Name, t.ID)
+		if t.Recommended {
+			line += " [recommended]"
+		}
+		if t.Description != "" {
+			line += " - " + t.Description
+		}
+		cobraCmd.Println(line)
+	}
+	cobraCmd.Println()
+	return nil
+}
+
+// fetchDemoTemplates lists the organization's demo templates. If the API can't
+// list them (e.g. an older server), it falls back to the single template in config.
+func fetchDemoTemplates(orgID string) []api.DemoTemplateItem {
+	resp, err := singletons.GetAPIClient().ListDemoTemplates(orgID)
+	if err == nil && len(resp.Templates) > 0 {
+		return resp.Templates
+	}
+
+	cfg := singletons.GetConfig()
+	return []api.DemoTemplateItem{{
+		ID:            "default",
+		Name:          "API usage demo",
+		CloneURLSSH:   cfg.DemoRepoSSH,
+		CloneURLHTTPS: cfg.DemoRepoHTTPS,
+	}}
+}
+
+// selectDemoTemplate picks the template matching ref by ID or case-insensitive
+// name. Without ref, a single template is selected automatically and several
+// are offered in a prompt with their descriptions, starting on the recommended one.
+func selectDemoTemplate(cobraCmd *cobra.Command, templates []api.DemoTemplateItem, ref string) (*api.DemoTemplateItem, error) {
+	if ref != "" {
+		for i, t := range templates {
+			if t.ID == ref || strings.EqualFold(t.Name, ref) {
+				return &templates[i], nil
+			}
+		}
+		return nil, &errors.CLIError{
+			Title:      fmt.Sprintf("Demo template %q not found", ref),
+			Suggestion: "Run 'major demo list' to see the available templates.",
+		}
+	}
+
+	if len(templates) == 1 {
+		return &templates[0], nil
+	}
+
+	var selected int
+	options := make([]huh.Option[int], len(templates))
+	for i, t := range templates {
+		label := t.Name
+		if t.Recommended {
+			label += " (recommended)"
+		}
+		if t.Description != "" {
+			label += " - " + t.Description
+		}
+		options[i] = huh.NewOption(label, i)
+	}
+	for i, t := range templates {
+		if t.Recommended {
+			selected = i
+			break
+		}
+	}
+
+	form := huh.NewForm(
+		huh.NewGroup(
+			huh.NewSelect[int]().
+				Title("Select a demo template").
+				Options(options...).
+				Value(&selected),
+		),
+	)
+	if err := form.Run(); err != nil {
+		return nil, errors.WrapError("failed to get selection", err)
+	}
+
+	cobraCmd.Printf("Selected template: %s\n", templates[selected].Name)
+	return &templates[selected], nil
+}
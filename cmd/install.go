@@ -64,6 +64,12 @@ func runInstall(cmd *cobra.Command) error {
 		return errors.WrapError("failed to get user home dir", err)
 	}
 
+	// Only add binDir to PATH if it isn't there already, e.g. for /usr/local/bin
+	pathEntry := fmt.Sprintf("export PATH=\"%s:$PATH\"\n", binDir)
+	if dirInPath(binDir, os.Getenv("PATH")) {
+		pathEntry = ""
+	}
+
 	shell := os.Getenv("SHELL")
 	var configFile string
 	var shellType string
@@ -83,8 +89,12 @@ func runInstall(cmd *cobra.Command) error {
 		shellType = "bash"
 	default:
 		// Fallback or skip
+		if pathEntry == "" {
+			cmd.Printf("Could not detect compatible shell (zsh/bash) to set up completions. %s is already on your PATH.\n", binDir)
+			return nil
+		}
 		cmd.Println("Could not detect compatible shell (zsh/bash). Please add the following to your path manually:")
-		cmd.Printf("  export PATH=\"%s:$PATH\"\n", binDir)
+		cmd.Printf("  %s", pathEntry)
 		return nil
 	}
 
@@ -117,11 +127,10 @@ func runInstall(cmd *cobra.Command) error {
 		// The safest robust way is to append to fpath and ensure compinit is called
 		completionEntry = fmt.Sprintf(`
 # Major CLI
-export PATH="%s:$PATH"
-export FPATH="%s:$FPATH"
+%sexport FPATH="%s:$FPATH"
 # Ensure compinit is loaded (if not already)
 autoload -U compinit && compinit
-`, binDir, completionsDir)
+`, pathEntry, completionsDir)
 
 	case "bash":
 		completionFile := filepath.Join(completionsDir, "major.bash")
@@ -137,9 +146,8 @@ autoload -U compinit && compinit
 
 		completionEntry = fmt.Sprintf(`
 # Major CLI
-export PATH="%s:$PATH"
-source "%s"
-`, binDir, completionFile)
+%ssource "%s"
+`, pathEntry, completionFile)
 	}
 
 	// Check if already configured
@@ -180,3 +188,25 @@ source "%s"
 
 	return nil
 }
+
+// dirInPath reports whether dir is one of the directories in pathEnv, comparing
+// cleaned paths with symlinks resolved
+func dirInPath(dir, pathEnv string) bool {
+	want := filepath.Clean(dir)
+	if resolved, err := filepath.EvalSymlinks(want); err == nil {
+		want = resolved
+	}
+	for _, entry := range filepath.SplitList(pathEnv) {
+		if entry == "" {
+			continue
+		}
+		entry = filepath.Clean(entry)
+		if resolved, err := filepath.EvalSymlinks(entry); err == nil {
+			entry = resolved
+		}
+		if entry == want {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDirInPath(t *testing.T) {
+	bin := t.TempDir()
+	other := t.TempDir()
+	link := filepath.Join(t.TempDir(), "bin-link")
+	if err := os.Symlink(bin, link); err != nil {
+		t.Fatal(err)
+	}
+	join := func(dirs ...string) string { return strings.Join(dirs, string(os.PathListSeparator)) }
+
+	tests := []struct {
+		name    string
+		pathEnv string
+		want    bool
+	}{
+		{"present", join(other, bin), true},
+		{"trailing slash", join(bin + string(os.PathSeparator)), true},
+		{"through a symlink", join(other, link), true},
+		{"absent", join(other), false},
+		{"empty", "", false},
+	}
+	for _, tt := range tests {
+		if got := dirInPath(bin, tt.pathEnv); got != tt.want {
+			t.Errorf("%s: dirInPath = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package org
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/major-technology/cli/clients/api"
+	mjrToken "github.com/major-technology/cli/clients/token"
+	"github.com/major-technology/cli/cmd/cmdtest"
+	clierrors "github.com/major-technology/cli/errors"
+)
+
+type fakeAPIClient struct {
+	api.APIClient
+	orgs []api.Organization
+}
+
+func (f *fakeAPIClient) GetOrganizations() (*api.OrganizationsResponse, error) {
+	return &api.OrganizationsResponse{Organizations: f.orgs}, nil
+}
+
+func TestList(t *testing.T) {
+	cmdtest.UseMockKeyring(t)
+	cmdtest.UseAPIClient(t, &fakeAPIClient{orgs: []api.Organization{
+		{ID: "org-1", Name: "Acme"},
+		{ID: "org-2", Name: "Globex"},
+	}})
+	if err := mjrToken.StoreDefaultOrg("org-2", "Globex"); err != nil {
+		t.Fatal(err)
+	}
+
+	stdout, _, err := cmdtest.Run(t, listCmd)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "\nYour Organizations:\n-------------------\n• Acme\n• Globex (default)\n\n"
+	if stdout != want {
+		t.Errorf("output = %q, want %q", stdout, want)
+	}
+
+	t.Cleanup(func() { flagListJSON = false })
+	stdout, _, err = cmdtest.Run(t, listCmd, "--json")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want = `[{"id":"org-1","name":"Acme","isSelected":false},{"id":"org-2","name":"Globex","isSelected":true}]` + "\n"
+	if stdout != want {
+		t.Errorf("--json output = %q, want %q", stdout, want)
+	}
+}
+
+func TestListNoOrganizations(t *testing.T) {
+	cmdtest.UseMockKeyring(t)
+	cmdtest.UseAPIClient(t, &fakeAPIClient{})
+
+	_, _, err := cmdtest.Run(t, listCmd)
+	if !errors.Is(err, clierrors.ErrorNoOrganizationsAvailable) {
+		t.Fatalf("err = %v, want ErrorNoOrganizationsAvailable", err)
+	}
+}
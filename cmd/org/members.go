@@ -0,0 +1,129 @@
+package org
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/major-technology/cli/clients/api"
+	mjrToken "github.com/major-technology/cli/clients/token"
+	"github.com/major-technology/cli/errors"
+	"github.com/major-technology/cli/singletons"
+	"github.com/spf13/cobra"
+)
+
+var (
+	flagMembersOrg    string
+	flagMembersOutput string
+)
+
+var membersCmd = &cobra.Command{
+	Use:   "members",
+	Short: "List the members of an organization",
+	Long:  `List the members of your default organization, or the one passed with --org, along with their roles.`,
+	RunE: func(cobraCmd *cobra.Command, args []string) error {
+		return runMembers(cobraCmd)
+	},
+}
+
+func init() {
+	membersCmd.Flags().StringVar(&flagMembersOrg, "org", "", "Organization ID or name (defaults to your default organization)")
+	membersCmd.Flags().StringVar(&flagMembersOutput, "output", "text", "Output format: text or json")
+}
+
+func runMembers(cobraCmd *cobra.Command) error {
+	if flagMembersOutput != "text" && flagMembersOutput != "json" {
+		return fmt.Errorf("invalid --output value %q, must be one of: text, json", flagMembersOutput)
+	}
+
+	apiClient := singletons.GetAPIClient()
+
+	orgID, orgName, err := resolveOrg(apiClient, flagMembersOrg)
+	if err != nil {
+		return err
+	}
+
+	membersResp, err := apiClient.GetOrganizationMembers(orgID)
+	if err != nil {
+		return errors.WrapError("failed to get organization members", err)
+	}
+
+	// Mark the current user; the list is still useful if this lookup fails
+	var currentUserID string
+	if verifyResp, err := apiClient.VerifyToken(); err == nil {
+		currentUserID = verifyResp.UserID
+	}
+
+	if flagMembersOutput == "json" {
+		type memberJSON struct {
+			UserID    string `json:"userId"`
+			Name      string `json:"name"`
+			Email     string `json:"email"`
+			Role      string `json:"role"`
+			IsCurrent bool   `json:"isCurrentUser"`
+		}
+
+		members := make([]memberJSON, len(membersResp.Members))
+		for i, m := range membersResp.Members {
+			members[i] = memberJSON{
+				UserID:    m.UserID,
+				Name:      m.Name,
+				Email:     m.Email,
+				Role:      m.Role,
+				IsCurrent: m.UserID == currentUserID,
+			}
+		}
+
+		data, err := json.Marshal(members)
+		if err != nil {
+			return errors.WrapError("failed to marshal JSON", err)
+		}
+		fmt.Fprintln(cobraCmd.OutOrStdout(), string(data))
+		return nil
+	}
+
+	cobraCmd.Printf("\nMembers of %s:\n", orgName)
+	cobraCmd.Println("-------------------")
+
+	for _, m := range membersResp.Members {
+		label := m.Email
+		if m.Name != "" {
+			label = fmt.Sprintf("%s <%s>", m.Name, m.Email)
+		}
+		line := fmt.Sprintf("• %s (%s)", label, m.Role)
+		if m.UserID == currentUserID {
+			line += " (you)"
+		}
+		cobraCmd.Println(line)
+	}
+
+	cobraCmd.Println()
+	return nil
+}
+
+// resolveOrg returns the ID and name of the organization matching ref by ID or
+// case-insensitive name. An empty ref selects the default organization.
+func resolveOrg(apiClient api.APIClient, ref string) (string, string, error) {
+	if ref == "" {
+		orgID, orgName, err := mjrToken.GetDefaultOrg()
+		if err != nil || orgID == "" {
+			return "", "", errors.ErrorNoOrganizationSelected
+		}
+		return orgID, orgName, nil
+	}
+
+	orgsResp, err := apiClient.GetOrganizations()
+	if err != nil {
+		return "", "", err
+	}
+	for _, org := range orgsResp.Organizations {
+		if org.ID == ref || strings.EqualFold(org.Name, ref) {
+			return org.ID, org.Name, nil
+		}
+	}
+
+	return "", "", &errors.CLIError{
+		Title:      fmt.Sprintf("Organization %q not found", ref),
+		Suggestion: "Run 'major org list' to see the organizations you belong to.",
+	}
+}
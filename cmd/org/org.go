@@ -22,4 +22,5 @@ func init() {
 	Cmd.AddCommand(whoamiCmd)
 	Cmd.AddCommand(listCmd)
 	Cmd.AddCommand(idCmd)
+	Cmd.AddCommand(membersCmd)
 }
@@ -22,6 +22,7 @@ func newCreateCmd() *cobra.Command {
 		Args:  cobra.ExactArgs(1),
 		PreRunE: middleware.Compose(
 			middleware.CheckLogin,
+			middleware.CheckGitInstalled,
 		),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runCreate(cmd, args[0], description)
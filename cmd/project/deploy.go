@@ -22,6 +22,7 @@ func newDeployCmd() *cobra.Command {
 		Short: "Deploy the latest compiled version of this project",
 		Long:  `Deploys a compiled project version: agents are created, updated, or deleted to match the version's definitions. Deletions require confirmation.`,
 		PreRunE: middleware.Compose(
+			middleware.RequireOnline,
 			middleware.CheckLogin,
 		),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -22,6 +22,8 @@ var addCmd = &cobra.Command{
 		middleware.CheckNodeInstalled,
 		middleware.CheckNodeVersion("22.12"),
 		middleware.CheckPnpmInstalled,
+		middleware.CheckGitInstalled,
+		middleware.CheckInGitRepository,
 	),
 	RunE: func(cobraCmd *cobra.Command, args []string) error {
 		return runAdd(cobraCmd)
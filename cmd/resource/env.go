@@ -13,15 +13,26 @@ import (
 	"github.com/spf13/cobra"
 )
 
-var flagEnvID string
+var (
+	flagEnvID   string
+	flagEnvList bool
+	flagEnvJSON bool
+)
 
 // envCmd represents the env command
 var envCmd = &cobra.Command{
 	Use:   "env",
 	Short: "View and change the environment for this application",
-	Long:  `View your current environment selection and switch between available environments.`,
+	Long: `View your current environment selection and switch between available environments.
+
+Use --list to print every environment with its ID instead of switching, e.g. to
+find the ID for --id in a script. It prints the same list as 'major resource env-list':
+
+  major resource env --list --json`,
 	PreRunE: middleware.Compose(
 		middleware.CheckLogin,
+		middleware.CheckGitInstalled,
+		middleware.CheckInGitRepository,
 	),
 	RunE: func(cobraCmd *cobra.Command, args []string) error {
 		return runEnv(cobraCmd)
@@ -30,9 +41,19 @@ var envCmd = &cobra.Command{
 
 func init() {
 	envCmd.Flags().StringVar(&flagEnvID, "id", "", "Environment ID to select non-interactively")
+	envCmd.Flags().BoolVar(&flagEnvList, "list", false, "List every environment with its ID instead of switching")
+	envCmd.Flags().BoolVar(&flagEnvJSON, "json", false, "Output --list in JSON format")
+	envCmd.MarkFlagsMutuallyExclusive("list", "id")
 }
 
 func runEnv(cobraCmd *cobra.Command) error {
+	if flagEnvJSON && !flagEnvList {
+		return &errors.CLIError{
+			Title:      "--json only applies with --list",
+			Suggestion: "Run 'major resource env --list --json' to print the environments as JSON.",
+		}
+	}
+
 	// Get application info from current directory
 	appInfo, err := utils.GetApplicationInfo("")
 	if err != nil {
@@ -54,6 +75,10 @@ func runEnv(cobraCmd *cobra.Command) error {
 		return errors.WrapError("failed to list environments", err)
 	}
 
+	if flagEnvList {
+		return printEnvironments(cobraCmd, envListResp.Environments, currentEnvResp.EnvironmentID, flagEnvJSON)
+	}
+
 	if len(envListResp.Environments) == 0 {
 		return &errors.CLIError{
 			Title:      "No environments available",
@@ -3,7 +3,9 @@ package resource
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 
+	"github.com/major-technology/cli/clients/api"
 	"github.com/major-technology/cli/errors"
 	"github.com/major-technology/cli/middleware"
 	"github.com/major-technology/cli/singletons"
@@ -19,6 +21,8 @@ var envListCmd = &cobra.Command{
 	Long:  `List all available environments and show which one is currently selected.`,
 	PreRunE: middleware.Compose(
 		middleware.CheckLogin,
+		middleware.CheckGitInstalled,
+		middleware.CheckInGitRepository,
 	),
 	RunE: func(cobraCmd *cobra.Command, args []string) error {
 		return runEnvList(cobraCmd)
@@ -47,24 +51,35 @@ func runEnvList(cobraCmd *cobra.Command) error {
 		return errors.WrapError("failed to list environments", err)
 	}
 
-	if flagEnvListJSON {
+	return printEnvironments(cobraCmd, envListResp.Environments, currentEnvResp.EnvironmentID, flagEnvListJSON)
+}
+
+// printEnvironments lists envs with their IDs, marking the current one and the
+// organization's default, either as text or as a JSON array for scripts
+func printEnvironments(cobraCmd *cobra.Command, envs []api.EnvironmentItem, currentEnvID *string, asJSON bool) error {
+	isCurrent := func(env api.EnvironmentItem) bool {
+		return currentEnvID != nil && env.ID == *currentEnvID
+	}
+
+	if asJSON {
 		type envJSON struct {
 			ID        string `json:"id"`
 			Name      string `json:"name"`
 			IsCurrent bool   `json:"isCurrent"`
+			IsDefault bool   `json:"isDefault"`
 		}
 
-		envs := make([]envJSON, len(envListResp.Environments))
-		for i, env := range envListResp.Environments {
-			isCurrent := currentEnvResp.EnvironmentID != nil && env.ID == *currentEnvResp.EnvironmentID
-			envs[i] = envJSON{
+		out := make([]envJSON, len(envs))
+		for i, env := range envs {
+			out[i] = envJSON{
 				ID:        env.ID,
 				Name:      env.Name,
-				IsCurrent: isCurrent,
+				IsCurrent: isCurrent(env),
+				IsDefault: env.IsDefault,
 			}
 		}
 
-		data, err := json.Marshal(envs)
+		data, err := json.Marshal(out)
 		if err != nil {
 			return errors.WrapError("failed to marshal JSON", err)
 		}
@@ -72,15 +87,27 @@ func runEnvList(cobraCmd *cobra.Command) error {
 		return nil
 	}
 
+	nameWidth := 0
+	for _, env := range envs {
+		nameWidth = max(nameWidth, len(env.Name))
+	}
+
 	// Human-readable output
 	cobraCmd.Println("\nEnvironments:")
 	cobraCmd.Println("-------------")
-	for _, env := range envListResp.Environments {
-		if currentEnvResp.EnvironmentID != nil && env.ID == *currentEnvResp.EnvironmentID {
-			cobraCmd.Printf("• %s (current)\n", env.Name)
-		} else {
-			cobraCmd.Printf("• %s\n", env.Name)
+	for _, env := range envs {
+		var marks []string
+		if isCurrent(env) {
+			marks = append(marks, "current")
+		}
+		if env.IsDefault {
+			marks = append(marks, "default")
+		}
+		line := fmt.Sprintf("• %-*s  %s", nameWidth, env.Name, env.ID)
+		if len(marks) > 0 {
+			line += " (" + strings.Join(marks, ", ") + ")"
 		}
+		cobraCmd.Println(line)
 	}
 	cobraCmd.Println()
 	return nil
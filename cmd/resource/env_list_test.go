@@ -0,0 +1,49 @@
+package resource
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/major-technology/cli/clients/api"
+	"github.com/spf13/cobra"
+)
+
+func TestPrintEnvironments(t *testing.T) {
+	envs := []api.EnvironmentItem{
+		{ID: "env-1", Name: "Development", IsDefault: true},
+		{ID: "env-2", Name: "Production"},
+	}
+	current := "env-2"
+
+	tests := []struct {
+		name   string
+		asJSON bool
+		want   string
+	}{
+		{
+			name: "text",
+			want: "\nEnvironments:\n-------------\n" +
+				"• Development  env-1 (default)\n" +
+				"• Production   env-2 (current)\n\n",
+		},
+		{
+			name:   "json",
+			asJSON: true,
+			want: `[{"id":"env-1","name":"Development","isCurrent":false,"isDefault":true},` +
+				`{"id":"env-2","name":"Production","isCurrent":true,"isDefault":false}]` + "\n",
+		},
+	}
+
+	for _, tt := range tests {
+		var out bytes.Buffer
+		cmd := &cobra.Command{}
+		cmd.SetOut(&out)
+
+		if err := printEnvironments(cmd, envs, &current, tt.asJSON); err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.name, err)
+		}
+		if out.String() != tt.want {
+			t.Errorf("%s output = %q, want %q", tt.name, out.String(), tt.want)
+		}
+	}
+}
@@ -17,6 +17,8 @@ var listCmd = &cobra.Command{
 	Long:  `List all resources in the organization, showing which are attached to the current app.`,
 	PreRunE: middleware.Compose(
 		middleware.CheckLogin,
+		middleware.CheckGitInstalled,
+		middleware.CheckInGitRepository,
 	),
 	RunE: func(cobraCmd *cobra.Command, args []string) error {
 		return runList(cobraCmd)
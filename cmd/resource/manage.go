@@ -1,6 +1,11 @@
 package resource
 
 import (
+	stderrors "errors"
+	"fmt"
+	"strings"
+
+	"github.com/major-technology/cli/clients/api"
 	"github.com/major-technology/cli/errors"
 	"github.com/major-technology/cli/middleware"
 	"github.com/major-technology/cli/singletons"
@@ -12,18 +17,35 @@ import (
 var manageCmd = &cobra.Command{
 	Use:   "manage",
 	Short: "Manage application resources",
-	Long:  `Select and configure resources for your application.`,
+	Long: `Select and configure resources for your application.
+
+Use --from-file to apply a declared set of resources instead of selecting them
+interactively. The file is a JSON or YAML list of resource names or IDs, or the
+output of 'major app resources list --output json'. Resources not in the file are
+detached, so the application ends up with exactly the declared set.`,
 	PreRunE: middleware.ChainParent(
 		middleware.CheckLogin,
 		middleware.CheckNodeInstalled,
 		middleware.CheckNodeVersion("22.12"),
 		middleware.CheckPnpmInstalled,
+		middleware.CheckGitInstalled,
+		middleware.CheckInGitRepository,
 	),
 	RunE: func(cobraCmd *cobra.Command, args []string) error {
 		return runManage(cobraCmd)
 	},
 }
 
+var (
+	flagManageFromFile string
+	flagManageYes      bool
+)
+
+func init() {
+	manageCmd.Flags().StringVar(&flagManageFromFile, "from-file", "", "Apply the resources declared in a JSON or YAML file instead of selecting interactively")
+	manageCmd.Flags().BoolVarP(&flagManageYes, "yes", "y", false, "Save the selection without confirming the resources being added and removed")
+}
+
 func runManage(cobraCmd *cobra.Command) error {
 	// Get application info from current directory
 	appInfo, err := utils.GetApplicationInfo("")
@@ -33,10 +55,24 @@ func runManage(cobraCmd *cobra.Command) error {
 
 	apiClient := singletons.GetAPIClient()
 
-	cobraCmd.Println("\nSelecting resources for your application...")
-	selectedResources, err := utils.SelectApplicationResources(cobraCmd, apiClient, appInfo.OrganizationID, appInfo.ApplicationID)
-	if err != nil {
-		return errors.ErrorFailedToSelectResourcesTryAgain
+	var selectedResources []api.ResourceItem
+	if flagManageFromFile != "" {
+		selectedResources, err = applyResourcesFile(cobraCmd, appInfo.OrganizationID, appInfo.ApplicationID, flagManageFromFile)
+		if err != nil {
+			return err
+		}
+	} else {
+		cobraCmd.Println("\nSelecting resources for your application...")
+		selectedResources, err = utils.SelectApplicationResourcesWithOptions(cobraCmd, apiClient, appInfo.OrganizationID, appInfo.ApplicationID, utils.SelectApplicationResourcesOptions{
+			SkipConfirm: flagManageYes,
+		})
+		if stderrors.Is(err, utils.ErrResourceSelectionCancelled) {
+			cobraCmd.Println("No changes made.")
+			return nil
+		}
+		if err != nil {
+			return errors.ErrorFailedToSelectResourcesTryAgain
+		}
 	}
 
 	if err := utils.AddResourcesToProject(cobraCmd, ".", selectedResources, appInfo.ApplicationID); err != nil {
@@ -45,3 +81,37 @@ func runManage(cobraCmd *cobra.Command) error {
 
 	return nil
 }
+
+// applyResourcesFile attaches exactly the resources declared in path to the application.
+// Every entry must match a resource in the organization, so a typo fails before anything changes.
+func applyResourcesFile(cobraCmd *cobra.Command, orgID, appID, path string) ([]api.ResourceItem, error) {
+	refs, err := utils.ReadResourcesFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	apiClient := singletons.GetAPIClient()
+	orgResources, err := apiClient.GetResources(orgID)
+	if err != nil {
+		return nil, errors.WrapError("failed to get resources", err)
+	}
+
+	resources, problems := utils.ResolveResourceRefs(refs, orgResources.Resources)
+	if len(problems) > 0 {
+		return nil, &errors.CLIError{
+			Title:      fmt.Sprintf("Can't apply %s: %s", path, strings.Join(problems, ", ")),
+			Suggestion: "Run 'major resource list' to see the available resources, then update the file.",
+		}
+	}
+
+	ids := make([]string, len(resources))
+	for i, r := range resources {
+		ids[i] = r.ID
+	}
+	if _, err := apiClient.SaveApplicationResources(orgID, appID, ids); err != nil {
+		return nil, errors.WrapError("failed to save application resources", err)
+	}
+
+	cobraCmd.Printf("✓ Applied %d resource(s) from %s\n", len(resources), path)
+	return resources, nil
+}
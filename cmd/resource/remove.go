@@ -21,6 +21,8 @@ var removeCmd = &cobra.Command{
 		middleware.CheckNodeInstalled,
 		middleware.CheckNodeVersion("22.12"),
 		middleware.CheckPnpmInstalled,
+		middleware.CheckGitInstalled,
+		middleware.CheckInGitRepository,
 	),
 	RunE: func(cobraCmd *cobra.Command, args []string) error {
 		return runRemove(cobraCmd)
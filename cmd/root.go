@@ -9,6 +9,7 @@ import (
 	"github.com/charmbracelet/lipgloss"
 	"github.com/major-technology/cli/clients/api"
 	"github.com/major-technology/cli/clients/config"
+	"github.com/major-technology/cli/clients/process"
 	mjrToken "github.com/major-technology/cli/clients/token"
 	"github.com/major-technology/cli/cmd/app"
 	cliconfig "github.com/major-technology/cli/cmd/config"
@@ -26,8 +27,13 @@ import (
 )
 
 var (
-	Version    = "dev"                // set by -ldflags, exported for middleware
-	configFile = "configs/local.json" // can also be set by -ldflags
+	Version     = "dev"                // set by -ldflags, exported for middleware
+	configFile  = "configs/local.json" // can also be set by -ldflags
+	flagChdir   string
+	flagConfig  string
+	flagOffline bool
+	flagNoCache bool
+	flagDebug   bool
 )
 
 func showLoginPromptIfNeeded(cmd *cobra.Command) bool {
@@ -71,15 +77,28 @@ var rootCmd = &cobra.Command{
 }
 
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
-		clierrors.PrintError(rootCmd, err)
-		os.Exit(1)
+	os.Exit(execute(rootCmd))
+}
+
+// execute runs cmd and prints any error it returns, returning the process exit code
+func execute(cmd *cobra.Command) int {
+	if err := cmd.Execute(); err != nil {
+		clierrors.PrintError(cmd, err)
+		return 1
 	}
+	return 0
 }
 
 func init() {
 	cobra.OnInitialize(initConfig)
 
+	rootCmd.PersistentFlags().StringVar(&flagConfig, "config", "", "Path to a JSON config file (or set MAJOR_CONFIG)")
+	rootCmd.PersistentFlags().StringVarP(&flagChdir, "chdir", "C", "", "Run as if major was started in this directory")
+	rootCmd.PersistentFlags().BoolVar(&flagOffline, "offline", false, "Don't use the network; show cached results where possible (or set MAJOR_OFFLINE=1)")
+	rootCmd.PersistentFlags().BoolVar(&flagNoCache, "no-cache", false, "Fetch organization and application lists instead of reusing recently cached ones (or set MAJOR_NO_CACHE=1)")
+	rootCmd.PersistentFlags().BoolVar(&flagDebug, "debug", false, "Log every API request, with its request ID, to stderr (or set MAJOR_DEBUG=1)")
+	rootCmd.PersistentFlags().Bool("skip-version-check", false, "Skip the CLI version check (or set MAJOR_SKIP_VERSION_CHECK=1)")
+
 	// Disable the default completion command (we use our own)
 	rootCmd.CompletionOptions.DisableDefaultCmd = true
 
@@ -134,14 +153,62 @@ func initConfig() {
 		}
 	}
 
+	// An explicit config file wins over the persistent environment
+	if env := os.Getenv("MAJOR_CONFIG"); env != "" {
+		configFile = env
+	}
+	if flagConfig != "" {
+		configFile = flagConfig
+	}
+
 	var err error
 	cfg, err := config.Load(configFile)
-	cobra.CheckErr(err)
+	if err != nil {
+		clierrors.PrintError(rootCmd, err)
+		os.Exit(1)
+	}
+
+	if flagOffline {
+		cfg.Offline = true
+	}
+	if flagNoCache {
+		cfg.NoCache = true
+	}
+	if flagDebug {
+		cfg.Debug = true
+	}
 
 	// Set config in singletons package
 	singletons.SetConfig(cfg)
 
+	// Select the credential backend before anything reads the token
+	mjrToken.SetCredentialStore(cfg.CredentialStore)
+
+	// Apply configured time limits before any subprocess is started
+	process.SetTimeouts(cfg.CommandTimeout, cfg.LongCommandTimeout)
+
 	// Initialize API client with base URL (token will be fetched automatically per-request)
 	client := api.NewClient(cfg.APIURL)
-	singletons.SetAPIClient(client)
+	client.SetVersion(Version)
+	client.SetOffline(cfg.Offline)
+	if cfg.Debug {
+		client.SetDebugLog(os.Stderr)
+	}
+
+	// Cache organization and application lists for pickers and offline mode
+	cacheOpts := api.CacheOptions{Offline: cfg.Offline, MaxAge: api.DefaultCacheMaxAge}
+	if cfg.NoCache {
+		cacheOpts.MaxAge = 0
+	}
+	if dir, err := api.ResponseCacheDir(); err == nil {
+		cacheOpts.Dir = dir
+	}
+	singletons.SetAPIClient(api.NewCachingClient(client, cacheOpts))
+
+	// Change directory last so relative config paths above still resolve from where major was started
+	if flagChdir != "" {
+		if err := os.Chdir(flagChdir); err != nil {
+			cobra.CheckErr(fmt.Errorf("failed to change directory: %w", err))
+		}
+	}
 }
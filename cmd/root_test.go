@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	clierrors "github.com/major-technology/cli/errors"
+	"github.com/spf13/cobra"
+)
+
+func TestExecutePrintsErrors(t *testing.T) {
+	var out bytes.Buffer
+	cmd := &cobra.Command{
+		Use:           "major",
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return clierrors.WrapError("failed to list applications", clierrors.ErrorNotLoggedIn)
+		},
+	}
+	cmd.SetOut(&out)
+	cmd.SetArgs(nil)
+
+	if code := execute(cmd); code != 1 {
+		t.Errorf("exit code = %d, want 1", code)
+	}
+	got := out.String()
+	for _, want := range []string{"failed to list applications", "Run 'major user login' to get started."} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output %q doesn't contain %q", got, want)
+		}
+	}
+	if strings.Contains(got, "user not logged in") {
+		t.Errorf("output %q shows the underlying error, want only the title and suggestion", got)
+	}
+}
+
+func TestExecuteSuccess(t *testing.T) {
+	var out bytes.Buffer
+	cmd := &cobra.Command{
+		Use: "major",
+		Run: func(cmd *cobra.Command, args []string) {},
+	}
+	cmd.SetOut(&out)
+	cmd.SetArgs(nil)
+
+	if code := execute(cmd); code != 0 {
+		t.Errorf("exit code = %d, want 0", code)
+	}
+	if out.Len() != 0 {
+		t.Errorf("output = %q, want nothing", out.String())
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/major-technology/cli/clients/process"
 	"github.com/major-technology/cli/errors"
 	"github.com/spf13/cobra"
 )
@@ -61,7 +62,7 @@ func detectInstallMethod() string {
 		// Check if brew is available
 		if _, err := exec.LookPath("brew"); err == nil {
 			// Check if major is installed via brew
-			brewListCmd := exec.Command("brew", "list", "major")
+			brewListCmd := process.Command("brew", "list", "major")
 			if err := brewListCmd.Run(); err == nil {
 				return "brew"
 			}
@@ -76,7 +77,7 @@ func updateViaBrew(cmd *cobra.Command, stepStyle, successStyle lipgloss.Style) e
 	cmd.Println(stepStyle.Render("▸ Updating via Homebrew..."))
 
 	// Update brew first
-	updateCmd := exec.Command("brew", "update")
+	updateCmd := process.CommandTimeout(process.LongTimeout, "brew", "update")
 	updateCmd.Stdout = os.Stdout
 	updateCmd.Stderr = os.Stderr
 	if err := updateCmd.Run(); err != nil {
@@ -84,7 +85,7 @@ func updateViaBrew(cmd *cobra.Command, stepStyle, successStyle lipgloss.Style) e
 	}
 
 	// Upgrade major
-	upgradeCmd := exec.Command("brew", "upgrade", "major")
+	upgradeCmd := process.CommandTimeout(process.LongTimeout, "brew", "upgrade", "major")
 	upgradeCmd.Stdout = os.Stdout
 	upgradeCmd.Stderr = os.Stderr
 
@@ -110,7 +111,7 @@ func updateViaDirect(cmd *cobra.Command, stepStyle, successStyle lipgloss.Style)
 	installScriptURL := "https://raw.githubusercontent.com/major-technology/cli/main/install.sh"
 
 	// Download and execute the install script
-	curlCmd := exec.Command("bash", "-c", fmt.Sprintf("curl -fsSL %s | bash", installScriptURL))
+	curlCmd := process.CommandTimeout(process.LongTimeout, "bash", "-c", fmt.Sprintf("curl -fsSL %s | bash", installScriptURL))
 	curlCmd.Stdout = os.Stdout
 	curlCmd.Stderr = os.Stderr
 	curlCmd.Stdin = os.Stdin // Allow password prompt for sudo
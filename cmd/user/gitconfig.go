@@ -2,6 +2,7 @@ package user
 
 import (
 	"errors"
+	"fmt"
 
 	"github.com/charmbracelet/huh"
 	mjrToken "github.com/major-technology/cli/clients/token"
@@ -9,7 +10,10 @@ import (
 	"github.com/spf13/cobra"
 )
 
-var flagGitconfigUsername string
+var (
+	flagGitconfigUsername string
+	flagGitconfigShow     bool
+)
 
 // gitconfigCmd represents the gitconfig command
 var gitconfigCmd = &cobra.Command{
@@ -19,7 +23,9 @@ var gitconfigCmd = &cobra.Command{
 
 You can provide the username via flag for non-interactive usage:
 
-  major user gitconfig --username "your-github-username"`,
+  major user gitconfig --username "your-github-username"
+
+Use --show to print the stored username without changing it.`,
 	RunE: func(cobraCmd *cobra.Command, args []string) error {
 		return runGitConfig(cobraCmd)
 	},
@@ -27,6 +33,8 @@ You can provide the username via flag for non-interactive usage:
 
 func init() {
 	gitconfigCmd.Flags().StringVar(&flagGitconfigUsername, "username", "", "GitHub username to store (skips interactive prompt)")
+	gitconfigCmd.Flags().BoolVar(&flagGitconfigShow, "show", false, "Print the stored GitHub username and exit")
+	gitconfigCmd.MarkFlagsMutuallyExclusive("username", "show")
 }
 
 func runGitConfig(cobraCmd *cobra.Command) error {
@@ -36,6 +44,16 @@ func runGitConfig(cobraCmd *cobra.Command) error {
 		return clierrors.WrapError("failed to get current GitHub username", err)
 	}
 
+	// Print the stored username to stdout so scripts can read it
+	if flagGitconfigShow {
+		if currentUsername == "" {
+			cobraCmd.Println("No GitHub username stored. Set one with 'major user gitconfig --username <name>'.")
+			return nil
+		}
+		fmt.Fprintln(cobraCmd.OutOrStdout(), currentUsername)
+		return nil
+	}
+
 	var githubUsername string
 
 	// If username flag is provided, use it directly
@@ -3,6 +3,8 @@ package user
 import (
 	"errors"
 	"fmt"
+	"os"
+	"strings"
 	"time"
 
 	"github.com/charmbracelet/huh"
@@ -15,24 +17,89 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var (
+	flagLoginToken string
+	flagLoginOrgID string
+	flagLoginNoOrg bool
+)
+
 // loginCmd represents the login command
 var loginCmd = &cobra.Command{
 	Use:   "login",
 	Short: "Login to the major app",
-	Long:  `Login and stores your session token`,
+	Long: `Login and stores your session token
+
+By default, this command opens a browser to authorize the CLI. For CI and other
+headless environments, pass a pre-issued token instead:
+
+  major user login --token "$MAJOR_TOKEN" --org "your-organization"
+
+--org takes the organization's ID or name and skips the organization prompt.
+
+The token can also be provided via the MAJOR_TOKEN environment variable.
+
+Use --no-org to only store the token, e.g. for scripts that don't need a default
+organization. Login then succeeds even if you don't belong to any organization.`,
 	RunE: func(cobraCmd *cobra.Command, args []string) error {
 		return runLogin(cobraCmd)
 	},
 }
 
+func init() {
+	loginCmd.Flags().StringVar(&flagLoginToken, "token", "", "Pre-issued token to store instead of using the browser flow (defaults to $MAJOR_TOKEN)")
+	loginCmd.Flags().StringVar(&flagLoginOrgID, "org", "", "Organization ID or name to set as default (skips interactive prompt)")
+	loginCmd.Flags().BoolVar(&flagLoginNoOrg, "no-org", false, "Only store the token, without selecting a default organization")
+	loginCmd.MarkFlagsMutuallyExclusive("org", "no-org")
+}
+
 func runLogin(cobraCmd *cobra.Command) error {
-	if err := doLogin(cobraCmd, true); err != nil {
+	token := flagLoginToken
+	if token == "" {
+		token = os.Getenv(mjrToken.TokenEnvVar)
+	}
+
+	if token != "" {
+		if err := doTokenLogin(cobraCmd, token); err != nil {
+			return err
+		}
+		cobraCmd.Println("✓ Successfully authenticated!")
+		return nil
+	}
+
+	if err := doLogin(cobraCmd, !flagLoginNoOrg); err != nil {
 		return err
 	}
+	if flagLoginNoOrg {
+		if err := clearDefaultOrg(); err != nil {
+			return err
+		}
+	}
 	printSuccessMessage(cobraCmd)
 	return nil
 }
 
+// doTokenLogin verifies a pre-issued token, stores it, and selects the default
+// organization unless --no-org is set, without opening a browser.
+func doTokenLogin(cobraCmd *cobra.Command, token string) error {
+	apiClient := singletons.GetAPIClient()
+	if _, err := apiClient.VerifyProvidedToken(token); err != nil {
+		return &clierrors.CLIError{
+			Title:      "Authentication failed",
+			Suggestion: "The provided token was rejected. Check that it is valid and hasn't expired.",
+			Err:        fmt.Errorf("%w: %w", clierrors.ErrorAuthenticationFailed, err),
+		}
+	}
+
+	if err := storeToken(token); err != nil {
+		return clierrors.WrapError("failed to store token", err)
+	}
+
+	if flagLoginNoOrg {
+		return clearDefaultOrg()
+	}
+	return selectDefaultOrg(cobraCmd, apiClient)
+}
+
 // doLogin performs the core login flow: browser auth, token storage, and org selection.
 // Used by both runLogin and RunLoginForLink.
 func doLogin(cobraCmd *cobra.Command, selectOrg bool) error {
@@ -54,39 +121,82 @@ func doLogin(cobraCmd *cobra.Command, selectOrg bool) error {
 		return clierrors.WrapError("authentication failed", err)
 	}
 
-	if err := mjrToken.StoreToken(token); err != nil {
+	if err := storeToken(token); err != nil {
 		return clierrors.WrapError("failed to store token", err)
 	}
 
 	if selectOrg {
-		// Fetch organizations (token will be fetched automatically)
-		orgsResp, err := apiClient.GetOrganizations()
-		if err != nil {
-			return clierrors.WrapError("failed to fetch organizations", err)
-		}
+		return selectDefaultOrg(cobraCmd, apiClient)
+	}
 
-		// Let user select default organization
-		if len(orgsResp.Organizations) > 0 {
-			selectedOrg, err := SelectOrganization(cobraCmd, orgsResp.Organizations)
-			if err != nil {
-				return clierrors.WrapError("failed to select organization", err)
-			}
+	return nil
+}
 
-			if err := mjrToken.StoreDefaultOrg(selectedOrg.ID, selectedOrg.Name); err != nil {
-				return clierrors.WrapError("failed to store default organization", err)
-			}
+// storeToken saves a new session's token and drops API responses cached for the
+// previous session, which may have belonged to another user
+func storeToken(token string) error {
+	if err := mjrToken.StoreToken(token); err != nil {
+		return err
+	}
+	apiClient.ClearResponseCache()
+	return nil
+}
 
-			cobraCmd.Printf("Default organization set to: %s\n", selectedOrg.Name)
-		} else {
-			return clierrors.ErrorNoOrganizationsAvailable
+// clearDefaultOrg forgets the default organization for --no-org, since one kept
+// from a previous login may belong to another user
+func clearDefaultOrg() error {
+	if err := mjrToken.DeleteDefaultOrg(); err != nil {
+		return clierrors.WrapError("failed to clear default organization", err)
+	}
+	return nil
+}
+
+// selectDefaultOrg stores the default organization, using --org (an ID or a
+// case-insensitive name) when provided and prompting otherwise.
+func selectDefaultOrg(cobraCmd *cobra.Command, client apiClient.APIClient) error {
+	// Fetch organizations (token will be fetched automatically)
+	orgsResp, err := client.GetOrganizations()
+	if err != nil {
+		return clierrors.WrapError("failed to fetch organizations", err)
+	}
+
+	if len(orgsResp.Organizations) == 0 {
+		return clierrors.ErrorNoOrganizationsAvailable
+	}
+
+	var selectedOrg *apiClient.Organization
+	if flagLoginOrgID != "" {
+		for i, org := range orgsResp.Organizations {
+			if org.ID == flagLoginOrgID || strings.EqualFold(org.Name, flagLoginOrgID) {
+				selectedOrg = &orgsResp.Organizations[i]
+				break
+			}
 		}
+		if selectedOrg == nil {
+			return &clierrors.CLIError{
+				Title:      fmt.Sprintf("Organization %q not found", flagLoginOrgID),
+				Suggestion: "You're logged in, but no default organization was set. Run 'major org list' to see the organizations you belong to, then 'major org select'.",
+				Err:        clierrors.ErrorOrganizationNotFound,
+			}
+		}
+	} else {
+		// Let user select default organization
+		selectedOrg, err = SelectOrganization(cobraCmd, orgsResp.Organizations)
+		if err != nil {
+			return clierrors.WrapError("failed to select organization", err)
+		}
+	}
+
+	if err := mjrToken.StoreDefaultOrg(selectedOrg.ID, selectedOrg.Name); err != nil {
+		return clierrors.WrapError("failed to store default organization", err)
 	}
 
+	cobraCmd.Printf("Default organization set to: %s\n", selectedOrg.Name)
 	return nil
 }
 
 // pollForToken polls POST /cli/login/poll until authenticated or timeout
-func pollForToken(cobraCmd *cobra.Command, client *apiClient.Client, deviceCode string, interval int, expiresIn int) (string, error) {
+func pollForToken(cobraCmd *cobra.Command, client apiClient.APIClient, deviceCode string, interval int, expiresIn int) (string, error) {
 	ticker := time.NewTicker(time.Duration(interval) * time.Second)
 	defer ticker.Stop()
 	timeoutChan := time.After(time.Duration(expiresIn) * time.Second)
@@ -99,7 +209,7 @@ func pollForToken(cobraCmd *cobra.Command, client *apiClient.Client, deviceCode
 			pollResp, err := client.PollLogin(deviceCode)
 			if err != nil {
 				// Check if authorization is still pending - this is an expected state
-				if errors.Is(err, clierrors.ErrorAuthorizationPending) {
+				if apiClient.IsAuthorizationPending(err) {
 					cobraCmd.Print(".")
 					continue
 				}
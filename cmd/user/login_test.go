@@ -0,0 +1,88 @@
+package user
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/major-technology/cli/clients/api"
+	mjrToken "github.com/major-technology/cli/clients/token"
+	"github.com/major-technology/cli/cmd/cmdtest"
+	clierrors "github.com/major-technology/cli/errors"
+)
+
+type fakeLoginClient struct {
+	api.APIClient
+	orgs []api.Organization
+}
+
+func (f *fakeLoginClient) VerifyProvidedToken(token string) (*api.VerifyTokenResponse, error) {
+	return &api.VerifyTokenResponse{Email: "ada@example.com"}, nil
+}
+
+func (f *fakeLoginClient) GetOrganizations() (*api.OrganizationsResponse, error) {
+	return &api.OrganizationsResponse{Organizations: f.orgs}, nil
+}
+
+// resetLoginFlags restores the login flags after the test, including whether
+// cobra considers them set, which --org and --no-org's exclusivity checks
+func resetLoginFlags(t *testing.T) {
+	t.Cleanup(func() {
+		flagLoginToken, flagLoginOrgID, flagLoginNoOrg = "", "", false
+		for _, name := range []string{"token", "org", "no-org"} {
+			loginCmd.Flags().Lookup(name).Changed = false
+		}
+	})
+}
+
+func TestTokenLoginNoOrg(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(mjrToken.TokenEnvVar, "")
+	cmdtest.UseMockKeyring(t)
+	cmdtest.UseAPIClient(t, &fakeLoginClient{})
+	resetLoginFlags(t)
+
+	// Without --no-org, a user with no organizations can't finish logging in
+	_, _, err := cmdtest.Run(t, loginCmd, "--token", "tok-1")
+	if !errors.Is(err, clierrors.ErrorNoOrganizationsAvailable) {
+		t.Fatalf("err = %v, want ErrorNoOrganizationsAvailable", err)
+	}
+
+	// A default organization from an earlier login, maybe of another user, is forgotten
+	if err := mjrToken.StoreDefaultOrg("org-1", "Acme"); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := cmdtest.Run(t, loginCmd, "--token", "tok-2", "--no-org"); err != nil {
+		t.Fatalf("login --no-org: unexpected error: %v", err)
+	}
+	if token, err := mjrToken.GetToken(); err != nil || token != "tok-2" {
+		t.Errorf("stored token = %q, %v; want tok-2", token, err)
+	}
+	if _, _, err := mjrToken.GetDefaultOrg(); err == nil {
+		t.Error("login --no-org kept a default organization")
+	}
+}
+
+func TestTokenLoginOrg(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(mjrToken.TokenEnvVar, "")
+	cmdtest.UseMockKeyring(t)
+	cmdtest.UseAPIClient(t, &fakeLoginClient{orgs: []api.Organization{
+		{ID: "org-1", Name: "Acme"},
+		{ID: "org-2", Name: "Globex"},
+	}})
+	resetLoginFlags(t)
+
+	for _, ref := range []string{"org-2", "globex"} {
+		if _, _, err := cmdtest.Run(t, loginCmd, "--token", "tok-1", "--org", ref); err != nil {
+			t.Fatalf("login --org %s: unexpected error: %v", ref, err)
+		}
+		if orgID, _, err := mjrToken.GetDefaultOrg(); err != nil || orgID != "org-2" {
+			t.Errorf("login --org %s: default org = %q, %v; want org-2", ref, orgID, err)
+		}
+	}
+
+	_, _, err := cmdtest.Run(t, loginCmd, "--token", "tok-1", "--org", "Initech")
+	if !errors.Is(err, clierrors.ErrorOrganizationNotFound) {
+		t.Errorf("unknown org: err = %v, want ErrorOrganizationNotFound", err)
+	}
+}
@@ -4,43 +4,79 @@ Copyright © 2025 NAME HERE <EMAIL ADDRESS>
 package user
 
 import (
+	stderrors "errors"
+
+	"github.com/major-technology/cli/clients/api"
 	mjrToken "github.com/major-technology/cli/clients/token"
 	"github.com/major-technology/cli/errors"
 	"github.com/major-technology/cli/singletons"
 	"github.com/spf13/cobra"
 )
 
+var (
+	flagLogoutForce bool
+	flagLogoutAll   bool
+)
+
 // logoutCmd represents the logout command
 var logoutCmd = &cobra.Command{
 	Use:   "logout",
 	Short: "Logout from the major app",
-	Long:  `Logout revokes your CLI token and removes it from local storage`,
+	Long: `Logout revokes your CLI token and removes it from local storage.
+
+If the token has already expired, local credentials are still cleared. Use --force
+to clear them even when the server can't be reached to revoke the token, and --all
+to also forget the GitHub username used for repository invitations.`,
 	RunE: func(cobraCmd *cobra.Command, args []string) error {
 		return runLogout(cobraCmd)
 	},
 }
 
+func init() {
+	logoutCmd.Flags().BoolVar(&flagLogoutForce, "force", false, "Clear local credentials even if the server can't revoke the token")
+	logoutCmd.Flags().BoolVar(&flagLogoutAll, "all", false, "Also remove the stored GitHub username, e.g. before handing over a shared machine")
+}
+
 func runLogout(cobraCmd *cobra.Command) error {
 	// Get the API client
 	apiClient := singletons.GetAPIClient()
 
 	// Call the logout endpoint to revoke the token (token will be fetched automatically)
-	err := apiClient.Logout()
-	if err != nil {
-		return errors.WrapError("failed to logout", err)
+	if err := apiClient.Logout(); err != nil {
+		switch {
+		case isDeadToken(err):
+			// Nothing left to revoke on the server, only local state to clear
+			cobraCmd.Println("Your session had already expired; clearing local credentials.")
+		case flagLogoutForce:
+			cobraCmd.Printf("Warning: Could not revoke the token on the server: %v\n", err)
+		default:
+			return &errors.CLIError{
+				Title:      "Failed to revoke your session",
+				Suggestion: "Check your connection and try again, or run 'major user logout --force' to clear local credentials anyway.",
+				Err:        err,
+			}
+		}
 	}
 
-	// Delete the token from local keyring
-	if err := mjrToken.DeleteToken(); err != nil {
-		return errors.WrapError("failed to delete local token", err)
+	// Clear everything we can, even if one of the deletes fails
+	var errs []error
+	errs = append(errs, mjrToken.DeleteToken(), mjrToken.DeleteDefaultOrg(), api.ClearResponseCache())
+	if flagLogoutAll {
+		errs = append(errs, mjrToken.DeleteGithubUsername())
 	}
-
-	// Delete the default organization from local keyring (if it exists)
-	err = mjrToken.DeleteDefaultOrg()
-	if err != nil {
-		return errors.WrapError("failed to delete default organization", err)
+	if err := stderrors.Join(errs...); err != nil {
+		return errors.WrapError("failed to clear local credentials", err)
 	}
 
 	cobraCmd.Println("Successfully logged out!")
 	return nil
 }
+
+// isDeadToken reports whether err means the token is already unusable
+// server-side, or there is no stored token to revoke
+func isDeadToken(err error) bool {
+	return api.IsInvalidToken(err) ||
+		api.IsUnauthorized(err) ||
+		stderrors.Is(err, errors.ErrorTokenNotActive) ||
+		stderrors.Is(err, errors.ErrorNotLoggedIn)
+}
@@ -0,0 +1,137 @@
+package user
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/major-technology/cli/clients/api"
+	"github.com/major-technology/cli/errors"
+	"github.com/major-technology/cli/middleware"
+	"github.com/major-technology/cli/singletons"
+	"github.com/spf13/cobra"
+)
+
+var flagSessionsJSON bool
+
+// sessionsCmd represents the user sessions command
+var sessionsCmd = &cobra.Command{
+	Use:   "sessions",
+	Short: "List your active CLI sessions",
+	Long: `List the CLI sessions signed in to your account, with the device and when each was
+created and last used. Use 'major user sessions revoke <id>' to sign out a machine
+you no longer control.`,
+	PreRunE: middleware.Compose(
+		middleware.CheckLogin,
+	),
+	RunE: func(cobraCmd *cobra.Command, args []string) error {
+		return runSessions(cobraCmd)
+	},
+}
+
+// sessionsRevokeCmd represents the user sessions revoke command
+var sessionsRevokeCmd = &cobra.Command{
+	Use:   "revoke <session-id>",
+	Short: "Revoke one of your CLI sessions",
+	Long:  `Revoke a CLI session so its token stops working. To end the session on this machine, use 'major user logout'.`,
+	Args:  cobra.ExactArgs(1),
+	PreRunE: middleware.Compose(
+		middleware.CheckLogin,
+	),
+	RunE: func(cobraCmd *cobra.Command, args []string) error {
+		return runSessionsRevoke(cobraCmd, args[0])
+	},
+}
+
+func init() {
+	sessionsCmd.Flags().BoolVar(&flagSessionsJSON, "json", false, "Output in JSON format")
+	sessionsCmd.AddCommand(sessionsRevokeCmd)
+}
+
+func runSessions(cobraCmd *cobra.Command) error {
+	resp, err := singletons.GetAPIClient().ListSessions()
+	if err != nil {
+		return errors.WrapError("failed to list sessions", err)
+	}
+
+	if flagSessionsJSON {
+		data, err := json.Marshal(resp.Sessions)
+		if err != nil {
+			return errors.WrapError("failed to marshal JSON", err)
+		}
+		fmt.Fprintln(cobraCmd.OutOrStdout(), string(data))
+		return nil
+	}
+
+	cobraCmd.Println("\nActive Sessions:")
+	cobraCmd.Println("-------------------")
+	for _, s := range resp.Sessions {
+		cobraCmd.Println(formatSession(s))
+	}
+	cobraCmd.Println()
+	return nil
+}
+
+// formatSession renders a session as a single list line
+func formatSession(s api.SessionItem) string {
+	device := s.DeviceName
+	if device == "" {
+		device = "unknown device"
+	}
+
+	line := fmt.Sprintf("• %s  %s, created %s, last used %s", s.ID, device, formatSessionTime(s.CreatedAt), formatSessionTime(s.LastUsedAt))
+	if s.Current {
+		line += " (this session)"
+	}
+	return line
+}
+
+// formatSessionTime shows an RFC3339 timestamp in local time, or the raw value if it doesn't parse
+func formatSessionTime(ts string) string {
+	if ts == "" {
+		return "never"
+	}
+	t, err := time.Parse(time.RFC3339, ts)
+	if err != nil {
+		return ts
+	}
+	return t.Local().Format("2006-01-02 15:04")
+}
+
+func runSessionsRevoke(cobraCmd *cobra.Command, sessionID string) error {
+	apiClient := singletons.GetAPIClient()
+
+	// Revoking this machine's session would leave a dead token behind, so point to logout instead
+	resp, err := apiClient.ListSessions()
+	if err != nil {
+		return errors.WrapError("failed to list sessions", err)
+	}
+
+	var found bool
+	for _, s := range resp.Sessions {
+		if s.ID != sessionID {
+			continue
+		}
+		if s.Current {
+			return &errors.CLIError{
+				Title:      "That is the session on this machine",
+				Suggestion: "Run 'major user logout' to end it.",
+			}
+		}
+		found = true
+		break
+	}
+	if !found {
+		return &errors.CLIError{
+			Title:      fmt.Sprintf("Session %q not found", sessionID),
+			Suggestion: "Run 'major user sessions' to see your active sessions.",
+		}
+	}
+
+	if err := apiClient.RevokeSession(sessionID); err != nil {
+		return errors.WrapError("failed to revoke session", err)
+	}
+
+	cobraCmd.Printf("✓ Revoked session %s\n", sessionID)
+	return nil
+}
@@ -4,25 +4,62 @@ import (
 	"fmt"
 	"os"
 
+	xt "github.com/charmbracelet/x/term"
 	mjrToken "github.com/major-technology/cli/clients/token"
+	clierrors "github.com/major-technology/cli/errors"
 	"github.com/spf13/cobra"
 )
 
+var (
+	flagTokenShow   bool
+	flagTokenHeader bool
+)
+
 var tokenCmd = &cobra.Command{
-	Use:    "token",
-	Short:  "Print the stored CLI token",
+	Use:   "token",
+	Short: "Print the stored CLI token",
+	Long: `Print the stored CLI token for use with other tools.
+
+When stdout is a terminal, the token is only shown with --show, so it doesn't end up
+in scrollback or screen recordings by accident. When piped, the token is printed as-is.
+
+  curl -H "$(major user token --header)" https://...`,
 	Hidden: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return runToken()
+		return runToken(cmd)
 	},
 }
 
-func runToken() error {
+func init() {
+	tokenCmd.Flags().BoolVar(&flagTokenShow, "show", false, "Print the token even when stdout is a terminal")
+	tokenCmd.Flags().BoolVar(&flagTokenHeader, "header", false, "Print an 'Authorization: Bearer ...' header line instead of the raw token")
+}
+
+func runToken(cmd *cobra.Command) error {
 	token, err := mjrToken.GetToken()
 	if err != nil {
 		return err
 	}
 
-	fmt.Fprint(os.Stdout, token)
+	output := token
+	if flagTokenHeader {
+		output = "Authorization: Bearer " + token
+	}
+
+	// Piped output is how tools like the Claude Code plugin read the token, so only guard terminals
+	if !xt.IsTerminal(os.Stdout.Fd()) {
+		fmt.Fprint(os.Stdout, output)
+		return nil
+	}
+
+	if !flagTokenShow {
+		return &clierrors.CLIError{
+			Title:      "Refusing to print the token to a terminal",
+			Suggestion: "Pass --show to display it anyway, or pipe the output into the tool that needs it.",
+		}
+	}
+
+	fmt.Fprintln(os.Stderr, "Warning: This token grants full access to your Major account. Don't share it, commit it, or paste it into logs or bug reports.")
+	fmt.Fprintln(os.Stdout, output)
 	return nil
 }
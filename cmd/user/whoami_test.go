@@ -0,0 +1,58 @@
+package user
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/major-technology/cli/clients/api"
+	mjrToken "github.com/major-technology/cli/clients/token"
+	"github.com/major-technology/cli/cmd/cmdtest"
+	clierrors "github.com/major-technology/cli/errors"
+)
+
+type fakeAPIClient struct {
+	api.APIClient
+	verify *api.VerifyTokenResponse
+	err    error
+}
+
+func (f *fakeAPIClient) VerifyToken() (*api.VerifyTokenResponse, error) {
+	return f.verify, f.err
+}
+
+func TestWhoami(t *testing.T) {
+	cmdtest.UseMockKeyring(t)
+	cmdtest.UseAPIClient(t, &fakeAPIClient{verify: &api.VerifyTokenResponse{Email: "ada@example.com"}})
+
+	stdout, _, err := cmdtest.Run(t, whoamiCmd)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "Logged in as: ada@example.com\n"; stdout != want {
+		t.Errorf("output = %q, want %q", stdout, want)
+	}
+
+	if err := mjrToken.StoreDefaultOrg("org-1", "Acme"); err != nil {
+		t.Fatal(err)
+	}
+	stdout, _, err = cmdtest.Run(t, whoamiCmd)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "Logged in as: ada@example.com\nDefault organization: Acme (org-1)\n"; stdout != want {
+		t.Errorf("output = %q, want %q", stdout, want)
+	}
+}
+
+func TestWhoamiInvalidToken(t *testing.T) {
+	cmdtest.UseMockKeyring(t)
+	cmdtest.UseAPIClient(t, &fakeAPIClient{err: clierrors.ErrorInvalidToken})
+
+	stdout, _, err := cmdtest.Run(t, whoamiCmd)
+	if !errors.Is(err, clierrors.ErrorInvalidToken) {
+		t.Fatalf("err = %v, want ErrorInvalidToken", err)
+	}
+	if stdout != "" {
+		t.Errorf("output = %q, want nothing", stdout)
+	}
+}
@@ -0,0 +1,193 @@
+package vars
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/major-technology/cli/errors"
+	"github.com/major-technology/cli/singletons"
+	"github.com/major-technology/cli/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	flagDiffEnv           string
+	flagDiffFile          string
+	flagDiffIncludeSystem bool
+)
+
+var diffCmd = &cobra.Command{
+	Use:   "diff",
+	Short: "Compare a local .env file with the server",
+	Long: `Compare a local dotenv file with the environment variables on the server, listing
+variables that are only on the server, only in the file, or set to different values.
+Values are masked.
+
+The values of platform-managed MAJOR_* variables, such as the short-lived
+MAJOR_JWT_TOKEN, change on their own and aren't compared unless you pass
+--include-system. A MAJOR_* variable missing from the file still counts.
+
+Exits non-zero when they differ, so CI can check that a .env is up to date.
+Unlike 'major vars pull', --env doesn't change your active environment.
+
+Examples:
+  major vars diff
+  major vars diff --env staging --file .env.staging`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runDiff(cmd)
+	},
+}
+
+func init() {
+	diffCmd.Flags().StringVar(&flagDiffEnv, "env", "", "Environment to compare with (defaults to your current environment)")
+	diffCmd.Flags().StringVar(&flagDiffFile, "file", ".env", "Path of the local dotenv file")
+	diffCmd.Flags().BoolVar(&flagDiffIncludeSystem, "include-system", false, "Also compare the values of platform-managed MAJOR_* variables")
+}
+
+// envDiff lists the keys that differ between a local file and the server
+type envDiff struct {
+	OnlyServer []string
+	OnlyLocal  []string
+	Changed    []string
+}
+
+func (d envDiff) empty() bool {
+	return len(d.OnlyServer) == 0 && len(d.OnlyLocal) == 0 && len(d.Changed) == 0
+}
+
+func runDiff(cmd *cobra.Command) error {
+	data, err := os.ReadFile(flagDiffFile)
+	if os.IsNotExist(err) {
+		return &errors.CLIError{
+			Title:      fmt.Sprintf("No env file at %s", flagDiffFile),
+			Suggestion: "Run 'major vars pull' to create it, or pass --file <path>.",
+		}
+	} else if err != nil {
+		return errors.WrapError("failed to read env file", err)
+	}
+	local := parseDotenv(string(data))
+
+	info, err := utils.GetApplicationInfo("")
+	if err != nil {
+		return errors.WrapError("failed to identify application", err)
+	}
+
+	env, err := resolveEnvironment(info.ApplicationID, flagDiffEnv)
+	if err != nil {
+		return err
+	}
+
+	server, err := singletons.GetAPIClient().GetApplicationEnvForEnvironment(info.OrganizationID, info.ApplicationID, env.ID)
+	if err != nil {
+		return errors.WrapError("failed to fetch environment variables", err)
+	}
+
+	diff := diffEnvVars(local, server, flagDiffIncludeSystem)
+	if diff.empty() {
+		cmd.Printf("✓ %s matches the %q environment\n", flagDiffFile, env.Name)
+		return nil
+	}
+
+	cmd.Printf("%s differs from the %q environment:\n", flagDiffFile, env.Name)
+	for _, key := range diff.OnlyServer {
+		cmd.Printf("  + %s (only on the server)\n", key)
+	}
+	for _, key := range diff.OnlyLocal {
+		cmd.Printf("  - %s (only in %s)\n", key, flagDiffFile)
+	}
+	for _, key := range diff.Changed {
+		cmd.Printf("  ~ %s (local %s, server %s)\n", key, maskValue(local[key]), maskValue(server[key]))
+	}
+
+	return &errors.CLIError{
+		Title:      fmt.Sprintf("%s is out of date", flagDiffFile),
+		Suggestion: "Run 'major vars pull' to update it.",
+	}
+}
+
+// diffEnvVars compares local with server, returning sorted keys in each group.
+// Changed values of MAJOR_* variables only count when includeSystem is set.
+func diffEnvVars(local, server map[string]string, includeSystem bool) envDiff {
+	var diff envDiff
+	for key, value := range server {
+		localValue, ok := local[key]
+		if !ok {
+			diff.OnlyServer = append(diff.OnlyServer, key)
+		} else if localValue != value && (includeSystem || !strings.HasPrefix(key, "MAJOR_")) {
+			diff.Changed = append(diff.Changed, key)
+		}
+	}
+	for key := range local {
+		if _, ok := server[key]; !ok {
+			diff.OnlyLocal = append(diff.OnlyLocal, key)
+		}
+	}
+	sort.Strings(diff.OnlyServer)
+	sort.Strings(diff.OnlyLocal)
+	sort.Strings(diff.Changed)
+	return diff
+}
+
+// parseDotenv reads KEY=value lines, skipping blank lines and comments. It
+// accepts an "export " prefix, single-quoted values taken literally, and
+// double-quoted values with the escapes quoteDotenvValue writes.
+func parseDotenv(content string) map[string]string {
+	envVars := make(map[string]string)
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		envVars[strings.TrimSpace(key)] = parseDotenvValue(strings.TrimSpace(value))
+	}
+	return envVars
+}
+
+// parseDotenvValue unquotes a single dotenv value
+func parseDotenvValue(value string) string {
+	switch {
+	case strings.HasPrefix(value, `"`):
+		var b strings.Builder
+		for i := 1; i < len(value); i++ {
+			c := value[i]
+			if c == '"' {
+				break
+			}
+			if c == '\\' && i+1 < len(value) {
+				i++
+				switch value[i] {
+				case 'n':
+					b.WriteByte('\n')
+				case 'r':
+					b.WriteByte('\r')
+				default:
+					b.WriteByte(value[i])
+				}
+				continue
+			}
+			b.WriteByte(c)
+		}
+		return b.String()
+	case strings.HasPrefix(value, "'"):
+		if end := strings.Index(value[1:], "'"); end >= 0 {
+			return value[1 : end+1]
+		}
+		return value[1:]
+	}
+
+	// Unquoted values end at an inline comment
+	if i := strings.Index(value, " #"); i >= 0 {
+		value = strings.TrimSpace(value[:i])
+	}
+	return value
+}
@@ -0,0 +1,70 @@
+package vars
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseDotenvReadsPulledFiles(t *testing.T) {
+	envVars := map[string]string{
+		"API_KEY":         "abc",
+		"GREETING":        "it's \"quoted\" $HOME\\n",
+		"MULTILINE":       "line one\nline two",
+		"EMPTY":           "",
+		"MAJOR_JWT_TOKEN": "jwt",
+	}
+
+	content, err := formatEnvVars(pullFormatDotenv, "header", envVars)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := parseDotenv(content); !reflect.DeepEqual(got, envVars) {
+		t.Errorf("parseDotenv(pulled file) = %q, want %q", got, envVars)
+	}
+}
+
+func TestParseDotenv(t *testing.T) {
+	content := `# comment
+export SHELL_STYLE=yes
+SINGLE='raw $HOME \n'
+INLINE=value # trailing comment
+  SPACED = padded
+not a variable
+`
+	want := map[string]string{
+		"SHELL_STYLE": "yes",
+		"SINGLE":      `raw $HOME \n`,
+		"INLINE":      "value",
+		"SPACED":      "padded",
+	}
+	if got := parseDotenv(content); !reflect.DeepEqual(got, want) {
+		t.Errorf("parseDotenv = %q, want %q", got, want)
+	}
+}
+
+func TestDiffEnvVars(t *testing.T) {
+	local := map[string]string{"SAME": "1", "STALE": "old", "LOCAL_ONLY": "x", "MAJOR_JWT_TOKEN": "expired"}
+	server := map[string]string{"SAME": "1", "STALE": "new", "NEW_B": "b", "NEW_A": "a", "MAJOR_JWT_TOKEN": "fresh", "MAJOR_API_BASE_URL": "https://api.example"}
+
+	got := diffEnvVars(local, server, false)
+	want := envDiff{
+		OnlyServer: []string{"MAJOR_API_BASE_URL", "NEW_A", "NEW_B"},
+		OnlyLocal:  []string{"LOCAL_ONLY"},
+		Changed:    []string{"STALE"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("diffEnvVars = %+v, want %+v", got, want)
+	}
+	if !diffEnvVars(server, server, true).empty() {
+		t.Error("diffEnvVars(server, server) is not empty")
+	}
+
+	// A rotated platform token alone isn't drift
+	rotated := map[string]string{"SAME": "1", "MAJOR_JWT_TOKEN": "fresh"}
+	if d := diffEnvVars(map[string]string{"SAME": "1", "MAJOR_JWT_TOKEN": "expired"}, rotated, false); !d.empty() {
+		t.Errorf("diffEnvVars with a rotated MAJOR_JWT_TOKEN = %+v, want empty", d)
+	}
+	if d := diffEnvVars(map[string]string{"SAME": "1", "MAJOR_JWT_TOKEN": "expired"}, rotated, true); !reflect.DeepEqual(d.Changed, []string{"MAJOR_JWT_TOKEN"}) {
+		t.Errorf("diffEnvVars with includeSystem: Changed = %v, want [MAJOR_JWT_TOKEN]", d.Changed)
+	}
+}
@@ -2,6 +2,7 @@ package vars
 
 import (
 	"bufio"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -17,8 +18,17 @@ import (
 )
 
 var (
-	flagPullEnv  string
-	flagPullFile string
+	flagPullEnv    string
+	flagPullFile   string
+	flagPullFormat string
+	flagPullStdout bool
+)
+
+// Output formats accepted by --format
+const (
+	pullFormatDotenv = "dotenv"
+	pullFormatJSON   = "json"
+	pullFormatShell  = "shell"
 )
 
 var pullCmd = &cobra.Command{
@@ -33,8 +43,13 @@ variables needed for local development. Overwrites the target file.
 If the target file is inside a git repository and is not yet ignored,
 appends it to the repo's .gitignore.
 
-Example:
-  major vars pull --env staging --file .env.staging`,
+--format picks the output: dotenv (the default), json for a flat object, or
+shell for export statements. --stdout prints instead of writing a file.
+
+Examples:
+  major vars pull --env staging --file .env.staging
+  major vars pull --file .env.local
+  eval "$(major vars pull --stdout --format shell)"`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return runPull(cmd)
 	},
@@ -42,10 +57,17 @@ Example:
 
 func init() {
 	pullCmd.Flags().StringVar(&flagPullEnv, "env", "", "Target environment name (defaults to your current environment)")
-	pullCmd.Flags().StringVar(&flagPullFile, "file", ".env", "Path to write the dotenv file")
+	pullCmd.Flags().StringVar(&flagPullFile, "file", ".env", "Path to write the variables to, e.g. .env.local")
+	pullCmd.Flags().StringVar(&flagPullFormat, "format", pullFormatDotenv, "Output format: dotenv, json, or shell")
+	pullCmd.Flags().BoolVar(&flagPullStdout, "stdout", false, "Print the variables instead of writing a file")
+	pullCmd.MarkFlagsMutuallyExclusive("file", "stdout")
 }
 
 func runPull(cmd *cobra.Command) error {
+	if err := validatePullFormat(flagPullFormat); err != nil {
+		return err
+	}
+
 	info, err := utils.GetApplicationInfo("")
 	if err != nil {
 		return errors.WrapError("failed to identify application", err)
@@ -80,10 +102,81 @@ func runPull(cmd *cobra.Command) error {
 		return errors.WrapError("failed to fetch environment variables", err)
 	}
 
+	// Shell output is eval'd, so keys that aren't plain variable names are left out
+	if flagPullFormat == pullFormatShell {
+		for _, key := range invalidKeys(envVars) {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Warning: skipping %q, which isn't a valid shell variable name\n", key)
+		}
+	}
+
+	header := fmt.Sprintf("Pulled from Major %q environment at %s", env.Name, time.Now().UTC().Format(time.RFC3339))
+	content, err := formatEnvVars(flagPullFormat, header, envVars)
+	if err != nil {
+		return err
+	}
+
+	// Only the variables go to stdout, so the output can be piped or eval'd
+	if flagPullStdout {
+		fmt.Fprint(cmd.OutOrStdout(), content)
+		return nil
+	}
+
+	targetPath, err := filepath.Abs(flagPullFile)
+	if err != nil {
+		return errors.WrapError("failed to resolve target file path", err)
+	}
+
+	if err := os.WriteFile(targetPath, []byte(content), 0600); err != nil {
+		return errors.WrapError("failed to write env file", err)
+	}
+
+	if err := ensureGitignore(cmd, targetPath); err != nil {
+		// Non-fatal - warn but do not fail the pull.
+		cmd.Printf("Warning: failed to update .gitignore: %v\n", err)
+	}
+
+	cmd.Printf("Environment: %s\n", env.Name)
+	cmd.Printf("Pulled %d variables to %s.\n", len(envVars), flagPullFile)
+	return nil
+}
+
+// validatePullFormat checks that format is one --format accepts
+func validatePullFormat(format string) error {
+	switch format {
+	case pullFormatDotenv, pullFormatJSON, pullFormatShell:
+		return nil
+	}
+	return &errors.CLIError{
+		Title:      fmt.Sprintf("Unknown format: %q", format),
+		Suggestion: "Use --format dotenv, json, or shell.",
+	}
+}
+
+// formatEnvVars renders envVars in format. Dotenv and shell output list
+// user-defined variables first, then MAJOR_* ones, each alphabetically, under a
+// header comment; JSON is a flat object with sorted keys and no header. Shell
+// output leaves out the invalidKeys, since it is meant to be eval'd.
+func formatEnvVars(format, header string, envVars map[string]string) (string, error) {
+	if format == pullFormatJSON {
+		data, err := json.MarshalIndent(envVars, "", "  ")
+		if err != nil {
+			return "", errors.WrapError("failed to marshal JSON", err)
+		}
+		return string(data) + "\n", nil
+	}
+
+	formatLine := formatDotenvLine
+	if format == pullFormatShell {
+		formatLine = formatShellLine
+	}
+
 	// Sort keys: user-defined first (alphabetical), then MAJOR_* (alphabetical).
 	userKeys := make([]string, 0, len(envVars))
 	majorKeys := make([]string, 0)
 	for k := range envVars {
+		if format == pullFormatShell && !keyPattern.MatchString(k) {
+			continue
+		}
 		if strings.HasPrefix(k, "MAJOR_") {
 			majorKeys = append(majorKeys, k)
 		} else {
@@ -94,35 +187,37 @@ func runPull(cmd *cobra.Command) error {
 	sort.Strings(majorKeys)
 
 	var builder strings.Builder
-	fmt.Fprintf(&builder, "# Pulled from Major %q environment at %s\n", env.Name, time.Now().UTC().Format(time.RFC3339))
+	fmt.Fprintf(&builder, "# %s\n", header)
 	builder.WriteString("# Do not edit MAJOR_* variables - they are managed by the platform\n\n")
 	for _, k := range userKeys {
-		builder.WriteString(formatDotenvLine(k, envVars[k]))
+		builder.WriteString(formatLine(k, envVars[k]))
 	}
 	if len(majorKeys) > 0 && len(userKeys) > 0 {
 		builder.WriteString("\n")
 	}
 	for _, k := range majorKeys {
-		builder.WriteString(formatDotenvLine(k, envVars[k]))
-	}
-
-	targetPath, err := filepath.Abs(flagPullFile)
-	if err != nil {
-		return errors.WrapError("failed to resolve target file path", err)
-	}
-
-	if err := os.WriteFile(targetPath, []byte(builder.String()), 0600); err != nil {
-		return errors.WrapError("failed to write dotenv file", err)
+		builder.WriteString(formatLine(k, envVars[k]))
 	}
+	return builder.String(), nil
+}
 
-	if err := ensureGitignore(cmd, targetPath); err != nil {
-		// Non-fatal - warn but do not fail the pull.
-		cmd.Printf("Warning: failed to update .gitignore: %v\n", err)
+// invalidKeys returns the sorted keys of envVars that aren't valid variable names,
+// e.g. ones containing spaces, ';' or '$(', which would run as code in shell output
+func invalidKeys(envVars map[string]string) []string {
+	var keys []string
+	for k := range envVars {
+		if !keyPattern.MatchString(k) {
+			keys = append(keys, k)
+		}
 	}
+	sort.Strings(keys)
+	return keys
+}
 
-	cmd.Printf("Environment: %s\n", env.Name)
-	cmd.Printf("Pulled %d variables to %s.\n", len(envVars), flagPullFile)
-	return nil
+// formatShellLine returns a single export statement. The value is single-quoted,
+// so the shell expands nothing in it.
+func formatShellLine(key, value string) string {
+	return fmt.Sprintf("export %s='%s'\n", key, strings.ReplaceAll(value, "'", `'\''`))
 }
 
 // formatDotenvLine returns a single KEY=value line with appropriate quoting.
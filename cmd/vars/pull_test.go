@@ -0,0 +1,101 @@
+package vars
+
+import (
+	"os/exec"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFormatEnvVars(t *testing.T) {
+	envVars := map[string]string{
+		"API_KEY":            "abc",
+		"GREETING":           "it's $HOME",
+		"MAJOR_JWT_TOKEN":    "jwt",
+		"MAJOR_API_BASE_URL": "https://api.example",
+	}
+
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{
+			format: pullFormatDotenv,
+			want: "# header\n# Do not edit MAJOR_* variables - they are managed by the platform\n\n" +
+				"API_KEY=abc\nGREETING=\"it's \\$HOME\"\n\nMAJOR_API_BASE_URL=https://api.example\nMAJOR_JWT_TOKEN=jwt\n",
+		},
+		{
+			format: pullFormatShell,
+			want: "# header\n# Do not edit MAJOR_* variables - they are managed by the platform\n\n" +
+				"export API_KEY='abc'\nexport GREETING='it'\\''s $HOME'\n\nexport MAJOR_API_BASE_URL='https://api.example'\nexport MAJOR_JWT_TOKEN='jwt'\n",
+		},
+		{
+			format: pullFormatJSON,
+			want:   "{\n  \"API_KEY\": \"abc\",\n  \"GREETING\": \"it's $HOME\",\n  \"MAJOR_API_BASE_URL\": \"https://api.example\",\n  \"MAJOR_JWT_TOKEN\": \"jwt\"\n}\n",
+		},
+	}
+
+	for _, tt := range tests {
+		got, err := formatEnvVars(tt.format, "header", envVars)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.format, err)
+		}
+		if got != tt.want {
+			t.Errorf("%s output =\n%s\nwant\n%s", tt.format, got, tt.want)
+		}
+	}
+}
+
+func TestShellFormatEvaluates(t *testing.T) {
+	sh, err := exec.LookPath("sh")
+	if err != nil {
+		t.Skip("sh not available")
+	}
+
+	value := "it's \"quoted\" $HOME `date` \\n"
+	script, err := formatEnvVars(pullFormatShell, "header", map[string]string{"TRICKY": value})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := exec.Command(sh, "-c", script+`printf %s "$TRICKY"`).Output()
+	if err != nil {
+		t.Fatalf("sh: %v", err)
+	}
+	if string(out) != value {
+		t.Errorf("evaluated value = %q, want %q", out, value)
+	}
+}
+
+func TestValidatePullFormat(t *testing.T) {
+	for _, format := range []string{"dotenv", "json", "shell"} {
+		if err := validatePullFormat(format); err != nil {
+			t.Errorf("validatePullFormat(%q) = %v", format, err)
+		}
+	}
+	if err := validatePullFormat("yaml"); err == nil || !strings.Contains(err.Error(), "yaml") {
+		t.Errorf("validatePullFormat(yaml) = %v, want an error naming the format", err)
+	}
+}
+
+func TestShellFormatSkipsInvalidKeys(t *testing.T) {
+	envVars := map[string]string{
+		"API_KEY":           "abc",
+		"X; touch pwned; Y": "1",
+		"$(touch pwned)":    "2",
+		"WITH SPACE":        "3",
+	}
+
+	script, err := formatEnvVars(pullFormatShell, "header", envVars)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "# header\n# Do not edit MAJOR_* variables - they are managed by the platform\n\nexport API_KEY='abc'\n"
+	if script != want {
+		t.Errorf("shell output =\n%s\nwant\n%s", script, want)
+	}
+
+	if got, want := invalidKeys(envVars), []string{"$(touch pwned)", "WITH SPACE", "X; touch pwned; Y"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("invalidKeys = %q, want %q", got, want)
+	}
+}
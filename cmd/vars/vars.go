@@ -17,6 +17,8 @@ Run these commands from inside a linked application directory.`,
 	Args: utils.NoArgs,
 	PersistentPreRunE: middleware.Compose(
 		middleware.CheckLogin,
+		middleware.CheckGitInstalled,
+		middleware.CheckInGitRepository,
 	),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		cmd.Help()
@@ -30,4 +32,5 @@ func init() {
 	Cmd.AddCommand(setCmd)
 	Cmd.AddCommand(unsetCmd)
 	Cmd.AddCommand(pullCmd)
+	Cmd.AddCommand(diffCmd)
 }
@@ -18,6 +18,8 @@ type CLIError struct {
 	// clients/api/errors.go's ToCLIError); zero for the static CLIError
 	// sentinels below, since those are shared singletons reused across calls.
 	StatusCode int
+	// RequestID is the server's ID for the failed request, shown so support can find it in the logs
+	RequestID string
 }
 
 // Standard error interface
@@ -39,6 +41,7 @@ func WrapError(msg string, ogerr error) *CLIError {
 			Title:      msg,
 			Suggestion: cliError.Suggestion,
 			Err:        fmt.Errorf("%s: %w", msg, cliError.Err),
+			RequestID:  cliError.RequestID,
 		}
 	}
 
@@ -60,6 +63,9 @@ func PrintError(cmd *cobra.Command, err error) {
 		Bold(true).
 		Foreground(lipgloss.Color("#87D7FF"))
 
+	requestIDStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("8")) // Gray
+
 	var title, suggestion string
 
 	// standard errors.As works perfectly with your custom struct
@@ -71,16 +77,34 @@ func PrintError(cmd *cobra.Command, err error) {
 		title = err.Error()
 	}
 
+	// Errors can carry raw API responses, so mask any credentials before printing
+	title = Redact(title)
+
 	var message string
 	if suggestion != "" {
 		message = fmt.Sprintf("%s\n\n%s", title, commandStyle.Render(suggestion))
 	} else {
 		message = title
 	}
+	if requestID := RequestID(err); requestID != "" {
+		message += "\n\n" + requestIDStyle.Render(fmt.Sprintf("Request ID: %s — include this when contacting support.", requestID))
+	}
 
 	cmd.Println(errorStyle.Render(message))
 }
 
+// RequestID returns the server request ID recorded on the first CLIError in err's
+// chain that has one, or "" if there is none
+func RequestID(err error) string {
+	for err != nil {
+		if cliErr, ok := err.(*CLIError); ok && cliErr.RequestID != "" {
+			return cliErr.RequestID
+		}
+		err = errors.Unwrap(err)
+	}
+	return ""
+}
+
 // Authentication/Session Errors
 var ErrorNotLoggedIn = &CLIError{
 	Title:      "Not logged in!",
@@ -213,6 +237,24 @@ var ErrorAPIUnavailable = &CLIError{
 	Err:        errors.New("API unavailable"),
 }
 
+var ErrorRateLimited = &CLIError{
+	Title:      "Too many requests",
+	Suggestion: "You're being rate limited by Major services. Wait a minute and try again.",
+	Err:        errors.New("rate limited"),
+}
+
+var ErrorOffline = &CLIError{
+	Title:      "This needs a network connection",
+	Suggestion: "Run it again without --offline (and unset MAJOR_OFFLINE) once you're connected.",
+	Err:        errors.New("offline mode"),
+}
+
+var ErrorOfflineNoCache = &CLIError{
+	Title:      "Nothing cached to show offline",
+	Suggestion: "Run the command once while online so its results are cached, then try again with --offline.",
+	Err:        errors.New("no cached response"),
+}
+
 // General Errors
 var ErrorInvalidInput = &CLIError{
 	Title:      "Invalid input",
@@ -301,6 +343,26 @@ var ErrorDuplicateAppName = &CLIError{
 	Err:        errors.New("duplicate application name"),
 }
 
+// ErrorAppSetupIncomplete reports a failure after an application was created on the server,
+// so the user finishes setting it up instead of re-running create into a duplicate name
+func ErrorAppSetupIncomplete(applicationID, recoveryCommand string, err error) *CLIError {
+	title := err.Error()
+	var suggestion string
+	var cliErr *CLIError
+	if errors.As(err, &cliErr) {
+		title = cliErr.Title
+		if cliErr.Suggestion != "" {
+			suggestion = cliErr.Suggestion + "\n\n"
+		}
+	}
+
+	return &CLIError{
+		Title:      title,
+		Suggestion: suggestion + fmt.Sprintf("The application (ID: %s) was already created. Once the problem is fixed, run '%s' to finish setting it up instead of creating it again.", applicationID, recoveryCommand),
+		Err:        fmt.Errorf("application %s created but not set up locally: %w", applicationID, err),
+	}
+}
+
 // API Error Codes - GitHub Integration (5000-5099)
 var ErrorGitHubRepoNotFound = &CLIError{
 	Title:      "GitHub repository not found",
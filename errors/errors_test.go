@@ -0,0 +1,68 @@
+package errors
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestPrintError(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		want    []string
+		notWant []string
+	}{
+		{
+			name: "cli error",
+			err:  ErrorNotLoggedIn,
+			want: []string{"Not logged in!", "Run 'major user login' to get started."},
+		},
+		{
+			name:    "wrapped cli error",
+			err:     WrapError("failed to fetch applications", ErrorNotLoggedIn),
+			want:    []string{"failed to fetch applications", "Run 'major user login' to get started."},
+			notWant: []string{"user not logged in"},
+		},
+		{
+			name: "plain error",
+			err:  errors.New("connection refused"),
+			want: []string{"connection refused"},
+		},
+		{
+			name: "request id",
+			err:  WrapError("failed to deploy", &CLIError{Title: "API Error (Code: 9999)", Err: errors.New("HTTP 500"), RequestID: "req-123"}),
+			want: []string{"failed to deploy", "Request ID: req-123 — include this when contacting support."},
+		},
+		{
+			name:    "credentials are redacted",
+			err:     errors.New("request failed: Authorization: Bearer abcdef0123456789"),
+			notWant: []string{"abcdef0123456789"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			cmd := &cobra.Command{}
+			cmd.SetOut(&out)
+
+			PrintError(cmd, tt.err)
+
+			got := out.String()
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("output %q doesn't contain %q", got, want)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(got, notWant) {
+					t.Errorf("output %q contains %q", got, notWant)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package errors
+
+import (
+	"regexp"
+	"strings"
+)
+
+const redacted = "[REDACTED]"
+
+var (
+	// authSchemePattern matches credentials following an HTTP auth scheme, e.g. "Bearer abc123"
+	authSchemePattern = regexp.MustCompile(`(?i)\b(bearer|basic)\s+[A-Za-z0-9\-._~+/]+=*`)
+
+	// jwtPattern matches a JSON Web Token (three base64url segments, header starting with "eyJ")
+	jwtPattern = regexp.MustCompile(`eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`)
+
+	// secretKeyValuePattern matches the value assigned to a secret-looking key in
+	// key=value, key: value, and "key": "value" forms
+	secretKeyValuePattern = regexp.MustCompile(`(?i)("?[\w.-]*(?:token|secret|password|passwd|api[_-]?key|private[_-]?key|credential)[\w.-]*"?\s*[:=]\s*)("[^"]*"|[^\s,;&}]+)`)
+)
+
+// Redact masks bearer tokens, JWTs, and the values of secret-looking keys in s
+// so it can be safely shown in error messages and logs.
+func Redact(s string) string {
+	s = authSchemePattern.ReplaceAllString(s, "$1 "+redacted)
+	s = secretKeyValuePattern.ReplaceAllStringFunc(s, func(match string) string {
+		parts := secretKeyValuePattern.FindStringSubmatch(match)
+		if strings.HasPrefix(parts[2], `"`) {
+			return parts[1] + `"` + redacted + `"`
+		}
+		return parts[1] + redacted
+	})
+	s = jwtPattern.ReplaceAllString(s, redacted)
+	return s
+}
@@ -2,12 +2,17 @@ package middleware
 
 import (
 	"fmt"
+	"os"
 	"os/exec"
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
+	xt "github.com/charmbracelet/x/term"
+	"github.com/major-technology/cli/clients/git"
+	"github.com/major-technology/cli/clients/process"
 	clierrors "github.com/major-technology/cli/errors"
 	"github.com/major-technology/cli/singletons"
 	"github.com/spf13/cobra"
@@ -52,8 +57,12 @@ func ChainParent(checks ...CommandCheck) func(cmd *cobra.Command, args []string)
 	}
 }
 
-// CheckLogin checks if the user is logged in and the session is valid
+// CheckLogin checks if the user is logged in and the session is valid.
+// Offline, the session can't be verified, so the check is skipped.
 func CheckLogin(cmd *cobra.Command, args []string) error {
+	if isOffline() {
+		return nil
+	}
 	client := singletons.GetAPIClient()
 
 	// VerifyToken checks if the token exists and is valid by calling the API
@@ -79,21 +88,25 @@ func CheckVersion(version string) CommandCheck {
 			return nil
 		}
 
-		client := singletons.GetAPIClient()
+		// Skip when the user opted out, e.g. for offline work
+		if versionCheckSkipped(cmd) || isOffline() {
+			return nil
+		}
 
-		resp, err := client.CheckVersion(version)
+		result, err := checkVersionCached(version)
 		if err != nil {
 			// Silently ignore version check errors to not disrupt user workflow
 			return nil
 		}
 
 		// Check for force upgrade
-		if resp.ForceUpgrade {
+		if result.ForceUpgrade {
 			return clierrors.ErrorForceUpgrade
 		}
 
-		// Check for optional upgrade
-		if resp.CanUpgrade {
+		// Check for optional upgrade, at most once a day, only for a person at a terminal,
+		// and not with telemetry off; forced upgrades above still apply
+		if result.CanUpgrade && result.upgradeNoticeDue(time.Now()) && !isMachineOutput(cmd) && !telemetryDisabled() {
 			warningStyle := lipgloss.NewStyle().
 				Bold(true).
 				Foreground(lipgloss.Color("#FFD700"))
@@ -109,12 +122,24 @@ func CheckVersion(version string) CommandCheck {
 
 			cmd.Println(message)
 			cmd.Println() // Add a blank line for spacing
+
+			result.NotifiedAt = time.Now()
+			storeVersionCache(result)
 		}
 
 		return nil
 	}
 }
 
+// RequireOnline fails early in offline mode, for commands that can't do anything
+// useful without the API and would otherwise fail partway through
+func RequireOnline(cmd *cobra.Command, args []string) error {
+	if isOffline() {
+		return clierrors.ErrorOffline
+	}
+	return nil
+}
+
 // CheckNodeInstalled checks if node is installed in the system path
 func CheckNodeInstalled(cmd *cobra.Command, args []string) error {
 	_, err := exec.LookPath("node")
@@ -124,6 +149,23 @@ func CheckNodeInstalled(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// CheckGitInstalled checks if git is installed in the system path
+func CheckGitInstalled(cmd *cobra.Command, args []string) error {
+	_, err := exec.LookPath("git")
+	if err != nil {
+		return clierrors.ErrorGitNotFound
+	}
+	return nil
+}
+
+// CheckInGitRepository checks that the command is run from inside a git repository
+func CheckInGitRepository(cmd *cobra.Command, args []string) error {
+	if !git.IsGitRepository() {
+		return clierrors.ErrorNotInGitRepository
+	}
+	return nil
+}
+
 // CheckPnpmInstalled checks if pnpm is installed in the system path
 func CheckPnpmInstalled(cmd *cobra.Command, args []string) error {
 	_, err := exec.LookPath("pnpm")
@@ -141,7 +183,7 @@ func CheckNodeVersion(minVersion string) CommandCheck {
 			return clierrors.ErrorNodeNotFound
 		}
 
-		cmdOut := exec.Command(path, "--version")
+		cmdOut := process.Command(path, "--version")
 		output, err := cmdOut.Output()
 		if err != nil {
 			return clierrors.WrapError("failed to check node version", err)
@@ -159,8 +201,48 @@ func CheckNodeVersion(minVersion string) CommandCheck {
 	}
 }
 
-// isVersionGTE returns true if v1 >= v2
-// This is a simple implementation assuming semver format x.y.z
+// versionCheckSkipped reports whether --skip-version-check or
+// MAJOR_SKIP_VERSION_CHECK asks to skip the version check
+func versionCheckSkipped(cmd *cobra.Command) bool {
+	if skip, err := cmd.Flags().GetBool("skip-version-check"); err == nil && skip {
+		return true
+	}
+	skip, _ := strconv.ParseBool(os.Getenv("MAJOR_SKIP_VERSION_CHECK"))
+	return skip
+}
+
+// isOffline reports whether --offline or MAJOR_OFFLINE asked to avoid the network
+func isOffline() bool {
+	cfg := singletons.GetConfig()
+	return cfg != nil && cfg.Offline
+}
+
+// telemetryDisabled reports whether 'major config set telemetry off' or
+// MAJOR_TELEMETRY=0 turned off non-essential features such as the upgrade notice
+func telemetryDisabled() bool {
+	cfg := singletons.GetConfig()
+	return cfg != nil && !cfg.Telemetry
+}
+
+// isMachineOutput reports whether the command's output is likely consumed by a
+// script: stdout or stderr isn't a terminal, or --quiet, --json or --output json is set
+func isMachineOutput(cmd *cobra.Command) bool {
+	if !xt.IsTerminal(os.Stdout.Fd()) || !xt.IsTerminal(os.Stderr.Fd()) {
+		return true
+	}
+	if quiet, err := cmd.Flags().GetBool("quiet"); err == nil && quiet {
+		return true
+	}
+	if asJSON, err := cmd.Flags().GetBool("json"); err == nil && asJSON {
+		return true
+	}
+	if output, err := cmd.Flags().GetString("output"); err == nil && output == "json" {
+		return true
+	}
+	return false
+}
+
+// isVersionGTE returns true if v1 >= v2, comparing major, minor and patch only
 func isVersionGTE(v1, v2 string) bool {
 	parts1 := parseVersion(v1)
 	parts2 := parseVersion(v2)
@@ -176,25 +258,21 @@ func isVersionGTE(v1, v2 string) bool {
 	return true
 }
 
+// versionPattern matches the leading major[.minor[.patch]] of a version,
+// ignoring any pre-release or build suffix such as "-nightly" or "+build"
+var versionPattern = regexp.MustCompile(`^v?(\d+)(?:\.(\d+))?(?:\.(\d+))?`)
+
+// parseVersion extracts the numeric major, minor and patch components of v.
+// Missing components are treated as 0.
 func parseVersion(v string) [3]int {
 	var parts [3]int
 
-	// Use regex to extract numbers
-	re := regexp.MustCompile(`(\d+)\.(\d+)\.(\d+)`)
-	matches := re.FindStringSubmatch(v)
-
-	if len(matches) == 4 {
-		for i := 1; i <= 3; i++ {
-			val, _ := strconv.Atoi(matches[i])
-			parts[i-1] = val
-		}
-	} else {
-		// Fallback for simpler versions like "18" or "18.1"
-		split := strings.Split(v, ".")
-		for i := 0; i < len(split) && i < 3; i++ {
-			val, _ := strconv.Atoi(split[i])
-			parts[i] = val
+	matches := versionPattern.FindStringSubmatch(strings.TrimSpace(v))
+	for i := 1; i < len(matches); i++ {
+		if matches[i] == "" {
+			continue
 		}
+		parts[i-1], _ = strconv.Atoi(matches[i])
 	}
 
 	return parts
@@ -0,0 +1,147 @@
+package middleware
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/major-technology/cli/clients/api"
+	"github.com/major-technology/cli/clients/config"
+	clierrors "github.com/major-technology/cli/errors"
+	"github.com/major-technology/cli/singletons"
+	"github.com/spf13/cobra"
+)
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		in   string
+		want [3]int
+	}{
+		{"22.12.0", [3]int{22, 12, 0}},
+		{"v22.12.0", [3]int{22, 12, 0}},
+		{"22.1.0-nightly", [3]int{22, 1, 0}},
+		{"v22.0.0+build", [3]int{22, 0, 0}},
+		{"22.1.0-nightly20240101+abc", [3]int{22, 1, 0}},
+		{"22.12", [3]int{22, 12, 0}},
+		{"v22", [3]int{22, 0, 0}},
+		{"22-rc", [3]int{22, 0, 0}},
+		{" v20.19.5\n", [3]int{20, 19, 5}},
+		{"", [3]int{0, 0, 0}},
+		{"garbage", [3]int{0, 0, 0}},
+	}
+
+	for _, tt := range tests {
+		if got := parseVersion(tt.in); got != tt.want {
+			t.Errorf("parseVersion(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestIsVersionGTE(t *testing.T) {
+	tests := []struct {
+		v1, v2 string
+		want   bool
+	}{
+		{"22.12.0", "22.12", true},
+		{"22.12.1", "22.12", true},
+		{"22.11.9", "22.12", false},
+		{"23.0.0", "22.12", true},
+		{"20.19.5", "22.12", false},
+		{"22.12.0-nightly", "22.12", true},
+		{"v22.13.0+build", "22.12", true},
+		{"22.13", "22.12", true},
+		{"23", "22.12", true},
+		{"22", "22.12", false},
+	}
+
+	for _, tt := range tests {
+		if got := isVersionGTE(tt.v1, tt.v2); got != tt.want {
+			t.Errorf("isVersionGTE(%q, %q) = %v, want %v", tt.v1, tt.v2, got, tt.want)
+		}
+	}
+}
+
+func TestVersionCheckSkipped(t *testing.T) {
+	newCmd := func(args ...string) *cobra.Command {
+		cmd := &cobra.Command{Use: "test"}
+		cmd.Flags().Bool("skip-version-check", false, "")
+		if err := cmd.ParseFlags(args); err != nil {
+			t.Fatalf("ParseFlags: %v", err)
+		}
+		return cmd
+	}
+
+	tests := []struct {
+		name string
+		args []string
+		env  string
+		want bool
+	}{
+		{"default", nil, "", false},
+		{"flag", []string{"--skip-version-check"}, "", true},
+		{"env true", nil, "1", true},
+		{"env false", nil, "false", false},
+		{"env invalid", nil, "maybe", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("MAJOR_SKIP_VERSION_CHECK", tt.env)
+			if got := versionCheckSkipped(newCmd(tt.args...)); got != tt.want {
+				t.Errorf("versionCheckSkipped() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOfflineChecks(t *testing.T) {
+	prev := singletons.GetConfig()
+	singletons.SetConfig(&config.Config{Offline: true})
+	t.Cleanup(func() { singletons.SetConfig(prev) })
+
+	cmd := &cobra.Command{}
+	if err := RequireOnline(cmd, nil); !errors.Is(err, clierrors.ErrorOffline) {
+		t.Errorf("RequireOnline: err = %v, want ErrorOffline", err)
+	}
+	// Offline, the login check must not reach the API client (none is set here)
+	if err := CheckLogin(cmd, nil); err != nil {
+		t.Errorf("CheckLogin: err = %v, want nil offline", err)
+	}
+
+	singletons.SetConfig(&config.Config{})
+	if err := RequireOnline(cmd, nil); err != nil {
+		t.Errorf("RequireOnline online: err = %v, want nil", err)
+	}
+}
+
+type fakeVersionClient struct {
+	api.APIClient
+	resp *api.CheckVersionResponse
+}
+
+func (f *fakeVersionClient) CheckVersion(currentVersion string) (*api.CheckVersionResponse, error) {
+	return f.resp, nil
+}
+
+func TestCheckVersionWithTelemetryOff(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("MAJOR_SKIP_VERSION_CHECK", "")
+	prevConfig, prevClient := singletons.GetConfig(), singletons.GetAPIClient()
+	singletons.SetConfig(&config.Config{Telemetry: false})
+	t.Cleanup(func() {
+		singletons.SetConfig(prevConfig)
+		singletons.SetAPIClient(prevClient)
+	})
+
+	path, err := versionCachePath()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Telemetry off only hides the optional notice; forced upgrades are still enforced
+	saveVersionCache(path, &versionCheckCache{Version: "1.0.0", CheckedAt: time.Now().Add(-versionCheckTTL)})
+	singletons.SetAPIClient(&fakeVersionClient{resp: &api.CheckVersionResponse{ForceUpgrade: true}})
+	if err := CheckVersion("1.0.0")(&cobra.Command{Use: "test"}, nil); !errors.Is(err, clierrors.ErrorForceUpgrade) {
+		t.Errorf("force upgrade: err = %v, want ErrorForceUpgrade", err)
+	}
+}
@@ -0,0 +1,120 @@
+package middleware
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/major-technology/cli/singletons"
+)
+
+const (
+	// versionCheckTTL is how long a version check result is reused before asking the API
+	// again. It's short so a newly required upgrade is enforced within the hour; the
+	// optional notice is rate limited separately by upgradeNoticeInterval.
+	versionCheckTTL = time.Hour
+
+	// upgradeNoticeInterval is the minimum time between optional upgrade notices
+	upgradeNoticeInterval = 24 * time.Hour
+)
+
+// versionCheckCache is the last version check result, stored in ~/.major/cache
+type versionCheckCache struct {
+	Version       string    `json:"version"`
+	ForceUpgrade  bool      `json:"forceUpgrade"`
+	CanUpgrade    bool      `json:"canUpgrade"`
+	LatestVersion *string   `json:"latestVersion,omitempty"`
+	CheckedAt     time.Time `json:"checkedAt"`
+	NotifiedAt    time.Time `json:"notifiedAt,omitempty"`
+}
+
+// isFresh reports whether the cached result can be used without a new check.
+// A force upgrade is never fresh, so it is re-checked on every run and lifted
+// as soon as the API stops requiring it.
+func (c *versionCheckCache) isFresh(now time.Time) bool {
+	return !c.ForceUpgrade && now.Sub(c.CheckedAt) < versionCheckTTL
+}
+
+// upgradeNoticeDue reports whether the optional upgrade notice hasn't been shown
+// within upgradeNoticeInterval
+func (c *versionCheckCache) upgradeNoticeDue(now time.Time) bool {
+	return now.Sub(c.NotifiedAt) >= upgradeNoticeInterval
+}
+
+func versionCachePath() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(homeDir, ".major", "cache", "version-check.json"), nil
+}
+
+// checkVersionCached returns the version check result for version, calling the
+// API only when the cached result is missing or stale. If the API can't be
+// reached, a cached force upgrade is still enforced.
+func checkVersionCached(version string) (*versionCheckCache, error) {
+	var cached *versionCheckCache
+	if path, err := versionCachePath(); err == nil {
+		cached = loadVersionCache(path, version)
+	}
+	if cached != nil && cached.isFresh(time.Now()) {
+		return cached, nil
+	}
+
+	resp, err := singletons.GetAPIClient().CheckVersion(version)
+	if err != nil {
+		if cached != nil && cached.ForceUpgrade {
+			return cached, nil
+		}
+		return nil, err
+	}
+
+	result := &versionCheckCache{
+		Version:       version,
+		ForceUpgrade:  resp.ForceUpgrade,
+		CanUpgrade:    resp.CanUpgrade,
+		LatestVersion: resp.LatestVersion,
+		CheckedAt:     time.Now(),
+	}
+	// Keep the notice rate limit across refreshes
+	if cached != nil {
+		result.NotifiedAt = cached.NotifiedAt
+	}
+
+	storeVersionCache(result)
+	return result, nil
+}
+
+// storeVersionCache saves cache to the default location, ignoring failures
+func storeVersionCache(cache *versionCheckCache) {
+	if path, err := versionCachePath(); err == nil {
+		saveVersionCache(path, cache)
+	}
+}
+
+// loadVersionCache reads the cached result for version. It returns nil if there
+// is none, it can't be read, or it was recorded for a different CLI version.
+func loadVersionCache(path, version string) *versionCheckCache {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+
+	var cache versionCheckCache
+	if json.Unmarshal(data, &cache) != nil || cache.Version != version {
+		return nil
+	}
+	return &cache
+}
+
+// saveVersionCache writes cache to path. Failures are ignored; the next run
+// simply checks again.
+func saveVersionCache(path string, cache *versionCheckCache) {
+	data, err := json.Marshal(cache)
+	if err != nil {
+		return
+	}
+	os.MkdirAll(filepath.Dir(path), 0755)
+	os.WriteFile(path, data, 0644)
+}
@@ -0,0 +1,101 @@
+package middleware
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestVersionCacheRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache", "version-check.json")
+	latest := "1.4.0"
+	checkedAt := time.Now().Add(-time.Hour).Truncate(time.Second)
+
+	saveVersionCache(path, &versionCheckCache{
+		Version:       "1.3.0",
+		CanUpgrade:    true,
+		LatestVersion: &latest,
+		CheckedAt:     checkedAt,
+		NotifiedAt:    checkedAt,
+	})
+
+	cache := loadVersionCache(path, "1.3.0")
+	if cache == nil {
+		t.Fatal("loadVersionCache() = nil, want cached result")
+	}
+	if !cache.CanUpgrade || cache.ForceUpgrade || cache.LatestVersion == nil || *cache.LatestVersion != latest {
+		t.Errorf("loadVersionCache() = %+v, want canUpgrade to %s", cache, latest)
+	}
+	if !cache.CheckedAt.Equal(checkedAt) || !cache.NotifiedAt.Equal(checkedAt) {
+		t.Errorf("CheckedAt, NotifiedAt = %v, %v, want %v", cache.CheckedAt, cache.NotifiedAt, checkedAt)
+	}
+
+	// A result recorded for another CLI version doesn't apply after an upgrade
+	if got := loadVersionCache(path, "1.4.0"); got != nil {
+		t.Errorf("loadVersionCache() for other version = %+v, want nil", got)
+	}
+}
+
+func TestLoadVersionCacheInvalid(t *testing.T) {
+	dir := t.TempDir()
+
+	if got := loadVersionCache(filepath.Join(dir, "missing.json"), "1.0.0"); got != nil {
+		t.Errorf("loadVersionCache() for missing file = %+v, want nil", got)
+	}
+
+	path := filepath.Join(dir, "corrupt.json")
+	if err := os.WriteFile(path, []byte("{not json"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if got := loadVersionCache(path, "1.0.0"); got != nil {
+		t.Errorf("loadVersionCache() for corrupt file = %+v, want nil", got)
+	}
+}
+
+func TestVersionCacheIsFresh(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name  string
+		cache versionCheckCache
+		want  bool
+	}{
+		{"recent", versionCheckCache{CheckedAt: now.Add(-10 * time.Minute)}, true},
+		{"recent upgrade available", versionCheckCache{CanUpgrade: true, CheckedAt: now.Add(-10 * time.Minute)}, true},
+		{"checked before a force upgrade could be published", versionCheckCache{CheckedAt: now.Add(-2 * time.Hour)}, false},
+		{"stale", versionCheckCache{CheckedAt: now.Add(-versionCheckTTL - time.Minute)}, false},
+		{"force upgrade", versionCheckCache{ForceUpgrade: true, CheckedAt: now}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.cache.isFresh(now); got != tt.want {
+				t.Errorf("isFresh() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUpgradeNoticeDue(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name       string
+		notifiedAt time.Time
+		want       bool
+	}{
+		{"never shown", time.Time{}, true},
+		{"shown recently", now.Add(-time.Hour), false},
+		{"shown a day ago", now.Add(-upgradeNoticeInterval), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache := versionCheckCache{CanUpgrade: true, NotifiedAt: tt.notifiedAt}
+			if got := cache.upgradeNoticeDue(now); got != tt.want {
+				t.Errorf("upgradeNoticeDue() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
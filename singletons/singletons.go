@@ -7,7 +7,7 @@ import (
 
 var (
 	cfg    *config.Config
-	client *apiClient.Client
+	client apiClient.APIClient
 )
 
 // SetConfig sets the global configuration
@@ -21,11 +21,11 @@ func GetConfig() *config.Config {
 }
 
 // SetAPIClient sets the global API client
-func SetAPIClient(c *apiClient.Client) {
+func SetAPIClient(c apiClient.APIClient) {
 	client = c
 }
 
 // GetAPIClient returns the global API client
-func GetAPIClient() *apiClient.Client {
+func GetAPIClient() apiClient.APIClient {
 	return client
 }
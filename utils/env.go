@@ -0,0 +1,52 @@
+package utils
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/major-technology/cli/errors"
+	"github.com/major-technology/cli/singletons"
+)
+
+// EnvFileOptions configures GenerateEnvFile
+type EnvFileOptions struct {
+	// EnvironmentID fetches this environment without changing the user's
+	// environment choice. Empty uses the currently-selected environment.
+	EnvironmentID string
+}
+
+// GenerateEnvFile fetches the application's environment variables and writes them
+// to targetDir/.env. Returns the file path and the variables that were written.
+func GenerateEnvFile(targetDir, orgID, appID string, opts EnvFileOptions) (string, map[string]string, error) {
+	envVars, err := singletons.GetAPIClient().GetApplicationEnvForEnvironment(orgID, appID, opts.EnvironmentID)
+	if err != nil {
+		return "", nil, errors.WrapError("failed to get environment variables", err)
+	}
+
+	envFilePath := filepath.Join(targetDir, ".env")
+
+	// Write to .env file, readable only by the owner since it holds secrets
+	if err := WriteFileAtomic(envFilePath, []byte(FormatEnvFile(envVars)), 0600); err != nil {
+		return "", nil, errors.WrapError("failed to write .env file", err)
+	}
+
+	return envFilePath, envVars, nil
+}
+
+// FormatEnvFile renders env vars as KEY=value lines, sorted so regenerating an
+// unchanged env produces identical output
+func FormatEnvFile(envVars map[string]string) string {
+	keys := make([]string, 0, len(envVars))
+	for key := range envVars {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var envContent strings.Builder
+	for _, key := range keys {
+		envContent.WriteString(fmt.Sprintf("%s=%s\n", key, envVars[key]))
+	}
+	return envContent.String()
+}
@@ -0,0 +1,15 @@
+package utils
+
+import "testing"
+
+func TestFormatEnvFileIsSorted(t *testing.T) {
+	got := FormatEnvFile(map[string]string{
+		"MAJOR_API_URL": "https://api.example.com",
+		"DATABASE_URL":  "postgres://localhost/app",
+		"API_KEY":       "abc",
+	})
+	want := "API_KEY=abc\nDATABASE_URL=postgres://localhost/app\nMAJOR_API_URL=https://api.example.com\n"
+	if got != want {
+		t.Fatalf("FormatEnvFile() = %q, want %q", got, want)
+	}
+}
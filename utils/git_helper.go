@@ -2,13 +2,14 @@ package utils
 
 import (
 	"fmt"
-	"os/exec"
+	"os"
 	"strings"
 	"time"
 
 	"github.com/charmbracelet/huh"
 	"github.com/major-technology/cli/clients/api"
 	"github.com/major-technology/cli/clients/git"
+	"github.com/major-technology/cli/clients/process"
 	mjrToken "github.com/major-technology/cli/clients/token"
 	"github.com/major-technology/cli/errors"
 	"github.com/major-technology/cli/singletons"
@@ -24,6 +25,28 @@ func (e *InvitationPendingError) Error() string {
 	return fmt.Sprintf("GitHub invitation pending. Accept at: %s", e.URL)
 }
 
+// collaboratorRetryDelays are the waits between attempts to add a GitHub collaborator
+var collaboratorRetryDelays = []time.Duration{1 * time.Second, 2 * time.Second, 4 * time.Second}
+
+// AddGithubCollaboratorWithRetry invites githubUsername to the application's
+// repository, retrying rate limits and unavailable upstreams with exponential
+// backoff. It returns the server's message about the invitation, if any.
+func AddGithubCollaboratorWithRetry(cmd *cobra.Command, apiClient api.APIClient, appID, githubUsername string) (string, error) {
+	for attempt := 0; ; attempt++ {
+		resp, err := apiClient.AddGithubCollaborators(appID, githubUsername)
+		if err == nil {
+			return resp.Message, nil
+		}
+		if !api.IsTransient(err) || attempt >= len(collaboratorRetryDelays) {
+			return "", err
+		}
+
+		delay := collaboratorRetryDelays[attempt]
+		cmd.Printf("Adding collaborator failed, retrying in %v...\n", delay)
+		time.Sleep(delay)
+	}
+}
+
 // GetApplicationID retrieves the application ID for the current git repository
 func GetApplicationID() (string, error) {
 	info, err := GetApplicationInfo("")
@@ -79,13 +102,63 @@ func GetApplicationInfo(dir string) (*api.GetApplicationByRepoResponse, error) {
 func CanUseSSH() bool {
 	// Test actual SSH connectivity to GitHub
 	// ssh -T returns exit code 1 even on success (no shell access), so we check output
-	cmd := exec.Command("ssh", "-T", "-o", "BatchMode=yes", "-o", "ConnectTimeout=5", "git@github.com")
+	cmd := process.CommandTimeout(gitAccessTimeout, "ssh", "-T", "-o", "BatchMode=yes", "-o", "ConnectTimeout=5", "git@github.com")
 	output, _ := cmd.CombinedOutput() // Ignore error since exit code 1 is expected on success
 
 	// GitHub returns "Hi <username>! You've successfully authenticated..." on success
 	return strings.Contains(string(output), "successfully authenticated")
 }
 
+// Git transports accepted by --git-protocol
+const (
+	GitProtocolAuto  = "auto"
+	GitProtocolSSH   = "ssh"
+	GitProtocolHTTPS = "https"
+)
+
+// ValidateGitProtocol checks a --git-protocol value
+func ValidateGitProtocol(protocol string) error {
+	switch protocol {
+	case "", GitProtocolAuto, GitProtocolSSH, GitProtocolHTTPS:
+		return nil
+	}
+	return fmt.Errorf("invalid --git-protocol value %q, must be one of: ssh, https, auto", protocol)
+}
+
+// SelectCloneURL picks the clone URL for protocol. "ssh" and "https" force that
+// transport and fail if the URL for it is missing; "auto" (or empty) uses SSH
+// when it works with GitHub and HTTPS otherwise. Returns the URL and the
+// method name ("SSH" or "HTTPS").
+func SelectCloneURL(protocol, sshURL, httpsURL string) (string, string, error) {
+	switch protocol {
+	case GitProtocolSSH:
+		if sshURL == "" {
+			return "", "", &errors.CLIError{
+				Title:      "No SSH clone URL available",
+				Suggestion: "Use --git-protocol https or auto instead.",
+			}
+		}
+		return sshURL, "SSH", nil
+	case GitProtocolHTTPS:
+		if httpsURL == "" {
+			return "", "", &errors.CLIError{
+				Title:      "No HTTPS clone URL available",
+				Suggestion: "Use --git-protocol ssh or auto instead.",
+			}
+		}
+		return httpsURL, "HTTPS", nil
+	case "", GitProtocolAuto:
+		if sshURL != "" && CanUseSSH() {
+			return sshURL, "SSH", nil
+		}
+		if httpsURL != "" {
+			return httpsURL, "HTTPS", nil
+		}
+		return "", "", errors.ErrorNoValidCloneMethodAvailable
+	}
+	return "", "", ValidateGitProtocol(protocol)
+}
+
 // CheckRepositoryAccess attempts to check if a repository is accessible via git ls-remote
 // Returns true if accessible, false otherwise
 func CheckRepositoryAccess(sshURL, httpsURL string) bool {
@@ -104,9 +177,15 @@ func CheckRepositoryAccess(sshURL, httpsURL string) bool {
 	return false
 }
 
-// testGitAccess tests if a git repository is accessible using git ls-remote
+// gitAccessTimeout bounds the network probes used to check repository access
+const gitAccessTimeout = 10 * time.Second
+
+// testGitAccess tests if a git repository is accessible using git ls-remote.
+// It returns false if the check doesn't finish within gitAccessTimeout.
 func testGitAccess(repoURL string) bool {
-	cmd := exec.Command("git", "ls-remote", "--heads", repoURL)
+	cmd := process.CommandTimeout(gitAccessTimeout, "git", "ls-remote", "--heads", repoURL)
+	// Fail instead of waiting for credentials that will never be typed
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
 	// Suppress output
 	cmd.Stdout = nil
 	cmd.Stderr = nil
@@ -212,8 +291,7 @@ func EnsureRepositoryAccessWithOptions(cmd *cobra.Command, appID string, sshURL
 	apiClient := singletons.GetAPIClient()
 
 	// Add user as GitHub collaborator
-	_, err := apiClient.AddGithubCollaborators(appID, githubUsername)
-	if err != nil {
+	if _, err := AddGithubCollaboratorWithRetry(cmd, apiClient, appID, githubUsername); err != nil {
 		return errors.WrapError("failed to add GitHub collaborator", err)
 	}
 
@@ -0,0 +1,94 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/major-technology/cli/clients/api"
+	clierrors "github.com/major-technology/cli/errors"
+	"github.com/spf13/cobra"
+)
+
+// collaboratorClient fails AddGithubCollaborators with each error in errs before succeeding
+type collaboratorClient struct {
+	api.APIClient
+	errs  []error
+	calls int
+}
+
+func (c *collaboratorClient) AddGithubCollaborators(applicationID, githubUsername string) (*api.AddGithubCollaboratorsResponse, error) {
+	c.calls++
+	if c.calls <= len(c.errs) {
+		return nil, c.errs[c.calls-1]
+	}
+	return &api.AddGithubCollaboratorsResponse{Success: true, Message: "Invitation sent to octocat"}, nil
+}
+
+func TestAddGithubCollaboratorWithRetry(t *testing.T) {
+	prev := collaboratorRetryDelays
+	collaboratorRetryDelays = []time.Duration{time.Millisecond, time.Millisecond}
+	t.Cleanup(func() { collaboratorRetryDelays = prev })
+
+	tests := []struct {
+		name      string
+		errs      []error
+		wantCalls int
+		wantErr   bool
+	}{
+		{name: "succeeds after transient failures", errs: []error{clierrors.ErrorAPIUnavailable, clierrors.ErrorRateLimited}, wantCalls: 3},
+		{name: "gives up after retries", errs: []error{clierrors.ErrorAPIUnavailable, clierrors.ErrorAPIUnavailable, clierrors.ErrorAPIUnavailable}, wantCalls: 3, wantErr: true},
+		{name: "failed adds are not retried", errs: []error{clierrors.ErrorGitHubCollaboratorAddFailed}, wantCalls: 1, wantErr: true},
+		{name: "permission errors are not retried", errs: []error{clierrors.ErrorGitHubRepoAccessDenied}, wantCalls: 1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &collaboratorClient{errs: tt.errs}
+			message, err := AddGithubCollaboratorWithRetry(&cobra.Command{}, client, "app-1", "octocat")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && message != "Invitation sent to octocat" {
+				t.Fatalf("message = %q, want the server's message", message)
+			}
+			if client.calls != tt.wantCalls {
+				t.Fatalf("calls = %d, want %d", client.calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestSelectCloneURL(t *testing.T) {
+	const sshURL = "git@github.com:org/repo.git"
+	const httpsURL = "https://github.com/org/repo.git"
+
+	tests := []struct {
+		name       string
+		protocol   string
+		ssh, https string
+		wantURL    string
+		wantMethod string
+		wantErr    bool
+	}{
+		{"forced ssh", GitProtocolSSH, sshURL, httpsURL, sshURL, "SSH", false},
+		{"forced https", GitProtocolHTTPS, sshURL, httpsURL, httpsURL, "HTTPS", false},
+		{"forced ssh without url", GitProtocolSSH, "", httpsURL, "", "", true},
+		{"forced https without url", GitProtocolHTTPS, sshURL, "", "", "", true},
+		{"auto without ssh url", GitProtocolAuto, "", httpsURL, httpsURL, "HTTPS", false},
+		{"empty without ssh url", "", "", httpsURL, httpsURL, "HTTPS", false},
+		{"auto without urls", GitProtocolAuto, "", "", "", "", true},
+		{"invalid", "git", sshURL, httpsURL, "", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			url, method, err := SelectCloneURL(tt.protocol, tt.ssh, tt.https)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SelectCloneURL() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if url != tt.wantURL || method != tt.wantMethod {
+				t.Errorf("SelectCloneURL() = %q, %q, want %q, %q", url, method, tt.wantURL, tt.wantMethod)
+			}
+		})
+	}
+}
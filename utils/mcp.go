@@ -2,14 +2,91 @@ package utils
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
 	"strings"
 
 	"github.com/major-technology/cli/clients/git"
 )
 
+// ErrMcpEnvVarsMissing is returned by GenerateMcpConfig when the application's env
+// doesn't provide the variables needed for the MCP server config. Templates that
+// don't use MCP omit them, so callers should treat this as a skip, not a failure.
+var ErrMcpEnvVarsMissing = errors.New("application env does not provide MAJOR_API_BASE_URL, MAJOR_JWT_TOKEN, and APPLICATION_ID")
+
+// mcpEnvVars are the application env vars GenerateMcpConfig needs to build the MCP server config.
+var mcpEnvVars = []string{"MAJOR_API_BASE_URL", "MAJOR_JWT_TOKEN", "APPLICATION_ID"}
+
+// HasMcpEnvVars reports whether envVars contains every variable GenerateMcpConfig requires.
+func HasMcpEnvVars(envVars map[string]string) bool {
+	for _, key := range mcpEnvVars {
+		if envVars[key] == "" {
+			return false
+		}
+	}
+	return true
+}
+
+// McpEditor describes where an AI editor expects its project-level MCP config
+// and how that file is structured.
+type McpEditor struct {
+	// Name is the value accepted by the --editors flag.
+	Name string
+	// DisplayName is the editor's name as shown to users.
+	DisplayName string
+	// ConfigPath is the config file location relative to the project root.
+	ConfigPath string
+
+	serversKey  string
+	includeType bool
+}
+
+// McpEditors lists the editors GenerateEditorMcpConfig can write configs for.
+// Claude Code comes first and is the default.
+var McpEditors = []McpEditor{
+	{Name: "claude", DisplayName: "Claude Code", ConfigPath: ".mcp.json", serversKey: "mcpServers", includeType: true},
+	{Name: "cursor", DisplayName: "Cursor", ConfigPath: filepath.Join(".cursor", "mcp.json"), serversKey: "mcpServers"},
+	{Name: "vscode", DisplayName: "VS Code", ConfigPath: filepath.Join(".vscode", "mcp.json"), serversKey: "servers", includeType: true},
+}
+
+// ParseMcpEditors resolves editor names (as passed to --editors) to their McpEditor
+// definitions, dropping duplicates and preserving order.
+func ParseMcpEditors(names []string) ([]McpEditor, error) {
+	var editors []McpEditor
+	seen := make(map[string]bool)
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" || seen[name] {
+			continue
+		}
+
+		editor, ok := lookupMcpEditor(name)
+		if !ok {
+			valid := make([]string, len(McpEditors))
+			for i, e := range McpEditors {
+				valid[i] = e.Name
+			}
+			return nil, fmt.Errorf("unknown editor %q (valid editors: %s)", name, strings.Join(valid, ", "))
+		}
+
+		seen[name] = true
+		editors = append(editors, editor)
+	}
+	return editors, nil
+}
+
+func lookupMcpEditor(name string) (McpEditor, bool) {
+	for _, editor := range McpEditors {
+		if editor.Name == name {
+			return editor, true
+		}
+	}
+	return McpEditor{}, false
+}
+
 // GenerateMcpConfig generates a .mcp.json file for Claude Code in the specified directory.
 // If targetDir is empty, it uses the current git repository root.
 // It uses the env vars from the application env endpoint to construct the MCP server config
@@ -19,14 +96,27 @@ import (
 // headersHelper, which doesn't require per-project setup. This function is kept for
 // backward compatibility with users who haven't installed the plugin yet.
 func GenerateMcpConfig(targetDir string, envVars map[string]string) (string, error) {
+	return GenerateEditorMcpConfig(targetDir, envVars, McpEditors[0], McpConfigOptions{})
+}
+
+// McpConfigOptions configures how MCP config files are written
+type McpConfigOptions struct {
+	// SkipGitignore leaves .gitignore untouched, for users who commit their MCP config
+	SkipGitignore bool
+}
+
+// GenerateEditorMcpConfig writes the MCP config for editor in the specified directory,
+// pointing at the same resource MCP endpoint as GenerateMcpConfig.
+// If targetDir is empty, it uses the current git repository root.
+func GenerateEditorMcpConfig(targetDir string, envVars map[string]string, editor McpEditor, opts McpConfigOptions) (string, error) {
+	if !HasMcpEnvVars(envVars) {
+		return "", ErrMcpEnvVarsMissing
+	}
+
 	apiBaseURL := envVars["MAJOR_API_BASE_URL"]
 	jwtToken := envVars["MAJOR_JWT_TOKEN"]
 	applicationID := envVars["APPLICATION_ID"]
 
-	if apiBaseURL == "" || jwtToken == "" || applicationID == "" {
-		return "", fmt.Errorf("missing required env vars for MCP config (MAJOR_API_BASE_URL, MAJOR_JWT_TOKEN, APPLICATION_ID)")
-	}
-
 	if targetDir == "" {
 		var err error
 		targetDir, err = git.GetRepoRoot()
@@ -37,15 +127,19 @@ func GenerateMcpConfig(targetDir string, envVars map[string]string) (string, err
 
 	mcpURL := fmt.Sprintf("%s/internal/apps/v1/%s/mcp", apiBaseURL, applicationID)
 
+	server := map[string]any{
+		"url": mcpURL,
+		"headers": map[string]string{
+			"x-major-jwt": jwtToken,
+		},
+	}
+	if editor.includeType {
+		server["type"] = "http"
+	}
+
 	config := map[string]any{
-		"mcpServers": map[string]any{
-			"major": map[string]any{
-				"type": "http",
-				"url":  mcpURL,
-				"headers": map[string]string{
-					"x-major-jwt": jwtToken,
-				},
-			},
+		editor.serversKey: map[string]any{
+			"major": server,
 		},
 	}
 
@@ -56,19 +150,26 @@ func GenerateMcpConfig(targetDir string, envVars map[string]string) (string, err
 
 	jsonBytes = append(jsonBytes, '\n')
 
-	mcpPath := filepath.Join(targetDir, ".mcp.json")
-	if err := os.WriteFile(mcpPath, jsonBytes, 0644); err != nil {
-		return "", fmt.Errorf("failed to write .mcp.json file: %w", err)
+	mcpPath := filepath.Join(targetDir, editor.ConfigPath)
+	if err := os.MkdirAll(filepath.Dir(mcpPath), 0755); err != nil {
+		return "", fmt.Errorf("failed to create directory for %s: %w", editor.ConfigPath, err)
+	}
+	if err := WriteFileAtomic(mcpPath, jsonBytes, 0600); err != nil {
+		return "", fmt.Errorf("failed to write %s file: %w", editor.ConfigPath, err)
 	}
 
-	// Ensure .mcp.json is in .gitignore
-	ensureGitignoreEntry(targetDir, ".mcp.json")
+	// Ensure the config is in .gitignore since it embeds the JWT
+	if !opts.SkipGitignore {
+		EnsureGitignoreEntry(targetDir, filepath.ToSlash(editor.ConfigPath))
+	}
 
 	return mcpPath, nil
 }
 
-// ensureGitignoreEntry appends an entry to .gitignore if it's not already present.
-func ensureGitignoreEntry(dir, entry string) {
+// EnsureGitignoreEntry appends an entry to .gitignore unless an existing pattern
+// already covers it. A negation pattern that re-includes the entry is taken as a
+// deliberate choice to commit the file, so nothing is added in that case either.
+func EnsureGitignoreEntry(dir, entry string) {
 	gitignorePath := filepath.Join(dir, ".gitignore")
 
 	content, err := os.ReadFile(gitignorePath)
@@ -78,12 +179,8 @@ func ensureGitignoreEntry(dir, entry string) {
 		return
 	}
 
-	// Check if entry already exists
-	lines := strings.Split(string(content), "\n")
-	for _, line := range lines {
-		if strings.TrimSpace(line) == entry {
-			return
-		}
+	if gitignoreMentions(string(content), entry) {
+		return
 	}
 
 	// Append the entry with a preceding newline if file doesn't end with one
@@ -93,3 +190,59 @@ func ensureGitignoreEntry(dir, entry string) {
 	}
 	_ = os.WriteFile(gitignorePath, append(content, []byte(suffix)...), 0644)
 }
+
+// gitignoreMentions reports whether any pattern in content matches entry, either
+// ignoring it or explicitly re-including it with a "!" negation.
+func gitignoreMentions(content, entry string) bool {
+	for _, line := range strings.Split(content, "\n") {
+		pattern := strings.TrimSpace(line)
+		if pattern == "" || strings.HasPrefix(pattern, "#") {
+			continue
+		}
+		pattern = strings.TrimPrefix(pattern, "!")
+		if gitignorePatternMatches(pattern, entry) {
+			return true
+		}
+	}
+	return false
+}
+
+// gitignorePatternMatches reports whether a single .gitignore pattern matches
+// entry, a slash-separated path relative to the repository root. It covers the
+// common forms: leading "/" or "**/", trailing "/" for directories, globs, and
+// slash-less patterns that match a name at any depth.
+func gitignorePatternMatches(pattern, entry string) bool {
+	pattern = strings.TrimPrefix(pattern, "./")
+	dirOnly := strings.HasSuffix(pattern, "/")
+	pattern = strings.TrimSuffix(pattern, "/")
+
+	anchored := strings.Contains(pattern, "/")
+	if strings.HasPrefix(pattern, "**/") {
+		pattern = strings.TrimPrefix(pattern, "**/")
+		anchored = strings.Contains(pattern, "/")
+	}
+	pattern = strings.TrimPrefix(pattern, "/")
+	if pattern == "" {
+		return false
+	}
+
+	parts := strings.Split(entry, "/")
+	// A directory pattern can only match a parent of the entry, never the file itself
+	candidates := len(parts)
+	if dirOnly {
+		candidates--
+	}
+
+	for i := 0; i < candidates; i++ {
+		var ok bool
+		if anchored {
+			ok, _ = path.Match(pattern, strings.Join(parts[:i+1], "/"))
+		} else {
+			ok, _ = path.Match(pattern, parts[i])
+		}
+		if ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,95 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGitignorePatternMatches(t *testing.T) {
+	tests := []struct {
+		pattern string
+		entry   string
+		want    bool
+	}{
+		{".mcp.json", ".mcp.json", true},
+		{"/.mcp.json", ".mcp.json", true},
+		{"./.mcp.json", ".mcp.json", true},
+		{"**/.mcp.json", ".mcp.json", true},
+		{"*.json", ".mcp.json", true},
+		{".mcp.*", ".mcp.json", true},
+		{"mcp.json", ".cursor/mcp.json", true},
+		{".cursor/", ".cursor/mcp.json", true},
+		{"/.cursor", ".cursor/mcp.json", true},
+		{".cursor/*.json", ".cursor/mcp.json", true},
+		{".vscode/", ".cursor/mcp.json", false},
+		{".mcp.json/", ".mcp.json", false},
+		{"/mcp.json", ".cursor/mcp.json", false},
+		{".env", ".mcp.json", false},
+	}
+
+	for _, tt := range tests {
+		if got := gitignorePatternMatches(tt.pattern, tt.entry); got != tt.want {
+			t.Errorf("gitignorePatternMatches(%q, %q) = %v, want %v", tt.pattern, tt.entry, got, tt.want)
+		}
+	}
+}
+
+func TestEnsureGitignoreEntry(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing string
+		want     string
+	}{
+		{
+			name:     "appends missing entry",
+			existing: "node_modules\n",
+			want:     "node_modules\n.mcp.json\n",
+		},
+		{
+			name:     "adds trailing newline first",
+			existing: "node_modules",
+			want:     "node_modules\n.mcp.json\n",
+		},
+		{
+			name:     "rooted path form",
+			existing: "/.mcp.json\n",
+			want:     "/.mcp.json\n",
+		},
+		{
+			name:     "covered by glob",
+			existing: "*.json\n",
+			want:     "*.json\n",
+		},
+		{
+			name:     "negation respected",
+			existing: "*.json\n!.mcp.json\n",
+			want:     "*.json\n!.mcp.json\n",
+		},
+		{
+			name:     "comment is not a pattern",
+			existing: "# .mcp.json\n",
+			want:     "# .mcp.json\n.mcp.json\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			gitignorePath := filepath.Join(dir, ".gitignore")
+			if err := os.WriteFile(gitignorePath, []byte(tt.existing), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			EnsureGitignoreEntry(dir, ".mcp.json")
+
+			got, err := os.ReadFile(gitignorePath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf(".gitignore = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,9 +2,9 @@ package utils
 
 import (
 	"encoding/json"
+	stderrors "errors"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strings"
 
@@ -12,9 +12,11 @@ import (
 	"github.com/charmbracelet/huh"
 	"github.com/major-technology/cli/clients/api"
 	"github.com/major-technology/cli/clients/git"
+	"github.com/major-technology/cli/clients/process"
 	"github.com/major-technology/cli/errors"
 	"github.com/major-technology/cli/singletons"
 	"github.com/spf13/cobra"
+	"go.yaml.in/yaml/v3"
 )
 
 // LocalResource represents a resource stored in resources.json
@@ -48,9 +50,25 @@ func ReadLocalResources(projectDir string) ([]LocalResource, error) {
 	return resources, nil
 }
 
+// ErrResourceSelectionCancelled is returned when the user declines to save a resource
+// selection at the confirmation step. Nothing was changed.
+var ErrResourceSelectionCancelled = stderrors.New("resource selection cancelled")
+
+// SelectApplicationResourcesOptions configures resource selection
+type SelectApplicationResourcesOptions struct {
+	// SkipConfirm saves the selection without first confirming what is added and removed
+	SkipConfirm bool
+}
+
 // SelectApplicationResources prompts the user to select resources for the application
 // Returns the selected resources with their full details
-func SelectApplicationResources(cmd *cobra.Command, apiClient *api.Client, orgID, appID string) ([]api.ResourceItem, error) {
+func SelectApplicationResources(cmd *cobra.Command, apiClient api.APIClient, orgID, appID string) ([]api.ResourceItem, error) {
+	return SelectApplicationResourcesWithOptions(cmd, apiClient, orgID, appID, SelectApplicationResourcesOptions{})
+}
+
+// SelectApplicationResourcesWithOptions prompts the user to select resources for the application
+// and, unless opts.SkipConfirm is set, confirms the added and removed resources before saving
+func SelectApplicationResourcesWithOptions(cmd *cobra.Command, apiClient api.APIClient, orgID, appID string, opts SelectApplicationResourcesOptions) ([]api.ResourceItem, error) {
 	// Fetch available resources
 	resourcesResp, err := apiClient.GetResources(orgID)
 	if err != nil {
@@ -84,10 +102,13 @@ func SelectApplicationResources(cmd *cobra.Command, apiClient *api.Client, orgID
 	// Create options for the multiselect
 	options := make([]huh.Option[string], len(resourcesResp.Resources))
 	for i, resource := range resourcesResp.Resources {
-		// Format: "Name - Description"
+		// Format: "Name (type) - Description"
 		label := resource.Name
+		if resource.Type != "" {
+			label = fmt.Sprintf("%s (%s)", label, resource.Type)
+		}
 		if resource.Description != "" {
-			label = fmt.Sprintf("%s - %s", resource.Name, resource.Description)
+			label = fmt.Sprintf("%s - %s", label, resource.Description)
 		}
 		options[i] = huh.NewOption(label, resource.ID)
 	}
@@ -129,6 +150,31 @@ func SelectApplicationResources(cmd *cobra.Command, apiClient *api.Client, orgID
 		return nil, errors.WrapError("failed to collect resource selection", err)
 	}
 
+	if !opts.SkipConfirm {
+		// Compare against what the server has, falling back to resources.json if it can't be fetched
+		currentIDs := make([]string, 0, len(existingResources))
+		if appResources, err := apiClient.GetApplicationResources(appID); err == nil {
+			for _, r := range appResources.Resources {
+				currentIDs = append(currentIDs, r.ID)
+			}
+		} else {
+			for _, r := range existingResources {
+				currentIDs = append(currentIDs, r.ID)
+			}
+		}
+
+		added, removed := DiffResourceIDs(currentIDs, selectedResourceIDs)
+		if len(added) > 0 || len(removed) > 0 {
+			confirmed, err := confirmResourceChanges(added, removed, resourcesResp.Resources)
+			if err != nil {
+				return nil, err
+			}
+			if !confirmed {
+				return nil, ErrResourceSelectionCancelled
+			}
+		}
+	}
+
 	_, err = apiClient.SaveApplicationResources(orgID, appID, selectedResourceIDs)
 	if err != nil {
 		return nil, err
@@ -137,6 +183,67 @@ func SelectApplicationResources(cmd *cobra.Command, apiClient *api.Client, orgID
 	return ResolveResourceItems(selectedResourceIDs, resourcesResp.Resources), nil
 }
 
+// DiffResourceIDs returns the IDs in selected but not current, and in current but not selected
+func DiffResourceIDs(current, selected []string) (added, removed []string) {
+	currentSet := make(map[string]bool, len(current))
+	for _, id := range current {
+		currentSet[id] = true
+	}
+	selectedSet := make(map[string]bool, len(selected))
+	for _, id := range selected {
+		selectedSet[id] = true
+		if !currentSet[id] {
+			added = append(added, id)
+		}
+	}
+	for _, id := range current {
+		if !selectedSet[id] {
+			removed = append(removed, id)
+		}
+	}
+	return added, removed
+}
+
+// confirmResourceChanges lists the resources being added and removed and asks the user to confirm
+func confirmResourceChanges(added, removed []string, orgResources []api.ResourceItem) (bool, error) {
+	names := make(map[string]string, len(orgResources))
+	for _, r := range orgResources {
+		label := r.Name
+		if r.Type != "" {
+			label = fmt.Sprintf("%s (%s)", label, r.Type)
+		}
+		names[r.ID] = label
+	}
+	label := func(id string) string {
+		if name, ok := names[id]; ok {
+			return name
+		}
+		return id
+	}
+
+	var lines []string
+	for _, id := range added {
+		lines = append(lines, "+ "+label(id))
+	}
+	for _, id := range removed {
+		lines = append(lines, "- "+label(id))
+	}
+
+	confirm := true
+	form := huh.NewForm(
+		huh.NewGroup(
+			huh.NewConfirm().
+				Title("Save these resource changes?").
+				Description(strings.Join(lines, "\n")).
+				Value(&confirm),
+		),
+	)
+	if err := form.Run(); err != nil {
+		return false, errors.WrapError("failed to read confirmation", err)
+	}
+	return confirm, nil
+}
+
 // ResolveResourceItems maps a list of resource IDs to their full ResourceItem details
 // from the org resource list. IDs not found in orgResources are skipped.
 func ResolveResourceItems(ids []string, orgResources []api.ResourceItem) []api.ResourceItem {
@@ -154,6 +261,95 @@ func ResolveResourceItems(ids []string, orgResources []api.ResourceItem) []api.R
 	return result
 }
 
+// resourceRef is one entry of a resources file: a bare resource name or ID, or a
+// resource object as exported by 'major app resources list --output json'
+type resourceRef string
+
+func (r *resourceRef) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*r = resourceRef(node.Value)
+		return nil
+	}
+
+	var item api.ResourceItem
+	if err := node.Decode(&item); err != nil {
+		return err
+	}
+	*r = resourceRef(item.ID)
+	if *r == "" {
+		*r = resourceRef(item.Name)
+	}
+	return nil
+}
+
+// ReadResourcesFile reads a declared resource set from a JSON or YAML file and returns
+// its entries, each a resource name or ID. The file is a list of names or IDs, or of
+// resources as exported by 'major app resources list --output json'.
+func ReadResourcesFile(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.WrapError("failed to read resources file", err)
+	}
+
+	// YAML is a superset of JSON, so this reads either
+	var declared []resourceRef
+	if err := yaml.Unmarshal(data, &declared); err != nil {
+		return nil, &errors.CLIError{
+			Title:      fmt.Sprintf("%s is not a valid resources file", path),
+			Suggestion: "The file must be a JSON or YAML list of resource names or IDs, or the output of 'major app resources list --output json'.",
+			Err:        err,
+		}
+	}
+
+	refs := make([]string, 0, len(declared))
+	for i, r := range declared {
+		ref := strings.TrimSpace(string(r))
+		if ref == "" {
+			return nil, &errors.CLIError{
+				Title:      fmt.Sprintf("Resource %d in %s has no name or id", i+1, path),
+				Suggestion: "Each entry needs a resource name or ID.",
+			}
+		}
+		refs = append(refs, ref)
+	}
+	return refs, nil
+}
+
+// ResolveResourceRefs matches resource names or IDs against the org resource list,
+// by ID first and then by exact name. Each ref that matches nothing, or whose name
+// matches several resources, is described in problems. Duplicates are dropped.
+func ResolveResourceRefs(refs []string, orgResources []api.ResourceItem) (resources []api.ResourceItem, problems []string) {
+	byID := make(map[string]api.ResourceItem, len(orgResources))
+	byName := make(map[string][]api.ResourceItem)
+	for _, r := range orgResources {
+		byID[r.ID] = r
+		byName[r.Name] = append(byName[r.Name], r)
+	}
+
+	seen := make(map[string]bool)
+	for _, ref := range refs {
+		r, ok := byID[ref]
+		if !ok {
+			switch matches := byName[ref]; len(matches) {
+			case 0:
+				problems = append(problems, fmt.Sprintf("%s (not found)", ref))
+				continue
+			case 1:
+				r = matches[0]
+			default:
+				problems = append(problems, fmt.Sprintf("%s (matches %d resources; use the ID)", ref, len(matches)))
+				continue
+			}
+		}
+
+		if !seen[r.ID] {
+			seen[r.ID] = true
+			resources = append(resources, r)
+		}
+	}
+	return resources, problems
+}
+
 // DetectFramework detects the framework used in the project by checking package.json dependencies
 func DetectFramework(projectDir string) string {
 	packageJsonPath := filepath.Join(projectDir, "package.json")
@@ -224,7 +420,7 @@ func AddResourcesToProject(cmd *cobra.Command, projectDir string, resources []ap
 	}
 
 	cmd.Println("  Installing dependencies...")
-	installCmd := exec.Command("pnpm", "install")
+	installCmd := process.CommandTimeout(process.LongTimeout, "pnpm", "install")
 	installCmd.Dir = projectDir
 	installCmd.Stdout = os.Stdout
 	installCmd.Stderr = os.Stderr
@@ -244,7 +440,7 @@ func AddResourcesToProject(cmd *cobra.Command, projectDir string, resources []ap
 			args = append(args, "--framework", framework)
 		}
 
-		pnpmCmd := exec.Command("pnpm", args...)
+		pnpmCmd := process.CommandTimeout(process.LongTimeout, "pnpm", args...)
 		pnpmCmd.Dir = projectDir
 		pnpmCmd.Stdout = os.Stdout
 		pnpmCmd.Stderr = os.Stderr
@@ -272,7 +468,7 @@ func AddResourcesToProject(cmd *cobra.Command, projectDir string, resources []ap
 			args = append(args, "--framework", framework)
 		}
 
-		pnpmCmd := exec.Command("pnpm", args...)
+		pnpmCmd := process.CommandTimeout(process.LongTimeout, "pnpm", args...)
 		pnpmCmd.Dir = projectDir
 		pnpmCmd.Stdout = os.Stdout
 		pnpmCmd.Stderr = os.Stderr
@@ -305,10 +501,11 @@ func AddResourcesToProject(cmd *cobra.Command, projectDir string, resources []ap
 // Returns the path to the generated file and the number of resources written.
 func GenerateResourcesFile(targetDir string) (string, int, error) {
 	// Get application ID from the specified directory (or current if empty)
-	applicationID, _, err := GetApplicationIDFromDir(targetDir)
+	appInfo, err := GetApplicationInfo(targetDir)
 	if err != nil {
 		return "", 0, err
 	}
+	applicationID := appInfo.ApplicationID
 
 	// Get API client
 	apiClient := singletons.GetAPIClient()
@@ -346,11 +543,14 @@ func GenerateResourcesFile(targetDir string) (string, int, error) {
 		}
 		content.WriteString(fmt.Sprintf("ID: %s\n", resource.ID))
 		content.WriteString(fmt.Sprintf("Name: %s\n", resource.Name))
+		if resource.Type != "" {
+			content.WriteString(fmt.Sprintf("Type: %s\n", resource.Type))
+		}
 		content.WriteString(fmt.Sprintf("Description: %s\n", resource.Description))
 	}
 
 	// Write to RESOURCES.md file
-	err = os.WriteFile(resourcesFilePath, []byte(content.String()), 0644)
+	err = WriteFileAtomic(resourcesFilePath, []byte(content.String()), 0644)
 	if err != nil {
 		return "", 0, errors.WrapError("failed to write RESOURCES.md file", err)
 	}
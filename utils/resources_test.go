@@ -0,0 +1,124 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/major-technology/cli/clients/api"
+)
+
+func TestReadResourcesFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:    "exported list",
+			file:    "resources.json",
+			content: `[{"id": "res-1", "name": "db", "type": "postgres"}, {"id": "res-2", "name": "cache", "type": "redis"}]`,
+			want:    []string{"res-1", "res-2"},
+		},
+		{
+			name:    "names and ids",
+			file:    "resources.json",
+			content: `["db", "res-2"]`,
+			want:    []string{"db", "res-2"},
+		},
+		{
+			name:    "object with only a name",
+			file:    "resources.json",
+			content: `[{"name": "db"}]`,
+			want:    []string{"db"},
+		},
+		{
+			name:    "yaml list",
+			file:    "resources.yaml",
+			content: "- db\n- id: res-2\n",
+			want:    []string{"db", "res-2"},
+		},
+		{
+			name:    "empty list detaches everything",
+			file:    "resources.json",
+			content: `[]`,
+			want:    []string{},
+		},
+		{
+			name:    "entry without name or id",
+			file:    "resources.json",
+			content: `[{"type": "postgres"}]`,
+			wantErr: true,
+		},
+		{
+			name:    "not a list",
+			file:    "resources.json",
+			content: `{"id": "res-1"}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		path := filepath.Join(t.TempDir(), tt.file)
+		if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := ReadResourcesFile(path)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("%s: expected error, got %v", tt.name, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: refs = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestResolveResourceRefs(t *testing.T) {
+	org := []api.ResourceItem{
+		{ID: "res-1", Name: "db"},
+		{ID: "res-2", Name: "cache"},
+		{ID: "res-3", Name: "shared"},
+		{ID: "res-4", Name: "shared"},
+	}
+
+	resources, problems := ResolveResourceRefs([]string{"db", "res-2", "res-1", "missing", "shared", "res-4"}, org)
+
+	var ids []string
+	for _, r := range resources {
+		ids = append(ids, r.ID)
+	}
+	if want := []string{"res-1", "res-2", "res-4"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("resolved = %v, want %v", ids, want)
+	}
+
+	want := []string{"missing (not found)", "shared (matches 2 resources; use the ID)"}
+	if !reflect.DeepEqual(problems, want) {
+		t.Errorf("problems = %v, want %v", problems, want)
+	}
+}
+
+func TestDiffResourceIDs(t *testing.T) {
+	added, removed := DiffResourceIDs([]string{"res-1", "res-2"}, []string{"res-2", "res-3"})
+	if !reflect.DeepEqual(added, []string{"res-3"}) {
+		t.Errorf("added = %v, want [res-3]", added)
+	}
+	if !reflect.DeepEqual(removed, []string{"res-1"}) {
+		t.Errorf("removed = %v, want [res-1]", removed)
+	}
+
+	added, removed = DiffResourceIDs([]string{"res-1"}, []string{"res-1"})
+	if len(added) != 0 || len(removed) != 0 {
+		t.Errorf("unchanged selection: added = %v, removed = %v, want none", added, removed)
+	}
+}
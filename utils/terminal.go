@@ -0,0 +1,17 @@
+package utils
+
+import (
+	"os"
+
+	xt "github.com/charmbracelet/x/term"
+)
+
+// IsInteractiveTerminal reports whether both stdin and stdout are attached to a
+// terminal that can render rich UI. CI runners often give stdout a pseudo-TTY
+// with no usable input, or set TERM=dumb, and Bubble Tea programs misbehave there.
+func IsInteractiveTerminal() bool {
+	if os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	return xt.IsTerminal(os.Stdout.Fd()) && xt.IsTerminal(os.Stdin.Fd())
+}